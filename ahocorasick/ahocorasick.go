@@ -0,0 +1,269 @@
+// Aho–Corasick multi-pattern string matching.
+//
+// The patterns are inserted into a trie whose nodes are then linked to the
+// node of their longest proper suffix (the failure link). Scanning a text
+// follows trie edges and falls back along failure links on a mismatch, so
+// every occurrence of every pattern is found in a single pass, in time
+// linear in the length of the text plus the number of matches.
+package ahocorasick
+
+import (
+	"bufio"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Match is an occurrence of a pattern in a text.
+// Start and End are byte offsets; End is exclusive.
+type Match struct {
+	Pattern string
+	Start   int
+	End     int
+}
+
+// node is a state of the automaton.
+type node struct {
+	next    map[rune]*node
+	fail    *node // longest proper suffix that is a node
+	dict    *node // nearest node on the failure chain that ends a pattern
+	pattern int   // index of the pattern ending here or -1
+}
+
+func newNode() *node {
+	return &node{next: map[rune]*node{}, pattern: -1}
+}
+
+// Matcher finds occurrences of a fixed set of patterns.
+// It is safe for concurrent use once built.
+type Matcher struct {
+	root     *node
+	patterns []string
+	lengths  []int // length of each pattern in runes
+	maxLen   int
+	fold     bool
+}
+
+// Builds a matcher for patterns. Empty and duplicate patterns are ignored.
+func New(patterns ...string) *Matcher {
+	return build(patterns, false)
+}
+
+// Builds a matcher that ignores case, using simple Unicode case folding.
+// Matches report the pattern as given.
+func NewCaseInsensitive(patterns ...string) *Matcher {
+	return build(patterns, true)
+}
+
+// folds r to its canonical case if the matcher ignores case.
+func (m *Matcher) normalize(r rune) rune {
+	if m.fold {
+		return fold(r)
+	}
+	return r
+}
+
+// fold returns the smallest rune in the simple case folding orbit of r,
+// so runes that fold to each other ('k', 'K' and the Kelvin sign,
+// 's' and 'ſ', 'σ' and 'ς') share one canonical form.
+func fold(r rune) rune {
+	canon := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < canon {
+			canon = f
+		}
+	}
+	return canon
+}
+
+func build(patterns []string, fold bool) *Matcher {
+	m := &Matcher{root: newNode(), fold: fold}
+
+	for _, p := range patterns {
+		if p == "" {
+			continue
+		}
+
+		n, length := m.root, 0
+		for _, r := range p {
+			r = m.normalize(r)
+			child, ok := n.next[r]
+			if !ok {
+				child = newNode()
+				n.next[r] = child
+			}
+			n = child
+			length++
+		}
+
+		if n.pattern >= 0 {
+			continue
+		}
+
+		n.pattern = len(m.patterns)
+		m.patterns = append(m.patterns, p)
+		m.lengths = append(m.lengths, length)
+		if length > m.maxLen {
+			m.maxLen = length
+		}
+	}
+
+	m.link()
+	return m
+}
+
+// link sets the failure and dictionary links breadth first,
+// so the links of shallower nodes are ready when needed.
+func (m *Matcher) link() {
+	m.root.fail = m.root
+	queue := []*node{}
+
+	for _, child := range m.root.next {
+		child.fail = m.root
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		for r, child := range n.next {
+			child.fail = m.step(n.fail, r)
+			if child.fail.pattern >= 0 {
+				child.dict = child.fail
+			} else {
+				child.dict = child.fail.dict
+			}
+			queue = append(queue, child)
+		}
+	}
+}
+
+// step returns the state reached from n on the (normalized) rune r.
+func (m *Matcher) step(n *node, r rune) *node {
+	for {
+		if next, ok := n.next[r]; ok {
+			return next
+		}
+
+		if n == m.root {
+			return n
+		}
+		n = n.fail
+	}
+}
+
+// Returns the patterns of the matcher.
+func (m *Matcher) Patterns() []string {
+	patterns := make([]string, len(m.patterns))
+	copy(patterns, m.patterns)
+	return patterns
+}
+
+// scanner runs the automaton over a rune stream, remembering the byte
+// offsets of the last maxLen runes so match starts can be reported.
+type scanner struct {
+	m      *Matcher
+	state  *node
+	starts []int // ring buffer of rune start offsets
+	count  int   // runes consumed
+}
+
+func (m *Matcher) newScanner() *scanner {
+	return &scanner{m: m, state: m.root, starts: make([]int, m.maxLen+1)}
+}
+
+// feed consumes the rune r found at byte offset off with the given size,
+// calling fn for each match ending with it. Returns false if fn did.
+func (s *scanner) feed(r rune, off, size int, fn func(Match) bool) bool {
+	s.starts[s.count%len(s.starts)] = off
+	s.count++
+
+	s.state = s.m.step(s.state, s.m.normalize(r))
+
+	// report the longest match first, then its suffixes
+	n := s.state
+	if n.pattern < 0 {
+		n = n.dict
+	}
+
+	for ; n != nil; n = n.dict {
+		first := s.count - s.m.lengths[n.pattern]
+		match := Match{
+			Pattern: s.m.patterns[n.pattern],
+			Start:   s.starts[first%len(s.starts)],
+			End:     off + size,
+		}
+
+		if !fn(match) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns every occurrence of every pattern in text, including overlapping
+// ones, ordered by end offset. Matches ending at the same offset are
+// ordered from the longest to the shortest.
+func (m *Matcher) FindAll(text string) []Match {
+	matches := []Match{}
+	m.Find(text, func(match Match) bool {
+		matches = append(matches, match)
+		return true
+	})
+	return matches
+}
+
+// Calls fn for each occurrence in text, in the order of FindAll,
+// until fn returns false.
+func (m *Matcher) Find(text string, fn func(match Match) bool) {
+	s := m.newScanner()
+	for off, r := range text {
+		// invalid bytes decode to utf8.RuneError with a size of 1
+		_, size := utf8.DecodeRuneInString(text[off:])
+		if !s.feed(r, off, size, fn) {
+			return
+		}
+	}
+}
+
+// Returns true if any pattern occurs in text. Stops at the first match.
+func (m *Matcher) Contains(text string) bool {
+	found := false
+	m.Find(text, func(Match) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// FindReader streams r through the matcher, calling fn for each occurrence
+// until fn returns false. Offsets are relative to the start of the stream.
+// Only the offsets of as many runes as the longest pattern are kept,
+// so arbitrarily large inputs can be scanned.
+//
+// Returns any read error other than io.EOF.
+func (m *Matcher) FindReader(r io.Reader, fn func(match Match) bool) error {
+	br, ok := r.(io.RuneReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	s := m.newScanner()
+	off := 0
+	for {
+		c, size, err := br.ReadRune()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !s.feed(c, off, size, fn) {
+			return nil
+		}
+		off += size
+	}
+}
@@ -0,0 +1,142 @@
+package ahocorasick_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/abiiranathan/algo/ahocorasick"
+)
+
+func TestFindAll(t *testing.T) {
+	m := ahocorasick.New("he", "she", "his", "hers", "", "he")
+
+	if len(m.Patterns()) != 4 {
+		t.Errorf("empty and duplicate patterns should be ignored, got %v", m.Patterns())
+	}
+
+	got := m.FindAll("ushers")
+	want := []ahocorasick.Match{
+		{Pattern: "she", Start: 1, End: 4},
+		{Pattern: "he", Start: 2, End: 4},
+		{Pattern: "hers", Start: 2, End: 6},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("match %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	if !m.Contains("this") || !m.Contains("nothing here") || m.Contains("xyz") {
+		t.Errorf("unexpected Contains results")
+	}
+
+	if len(m.FindAll("")) != 0 {
+		t.Errorf("empty text should have no matches")
+	}
+}
+
+func TestFindAllUnicode(t *testing.T) {
+	m := ahocorasick.New("東京", "京都")
+	text := "東京都"
+
+	got := m.FindAll(text)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 matches, got %v", got)
+	}
+
+	for _, match := range got {
+		if text[match.Start:match.End] != match.Pattern {
+			t.Errorf("offsets %d:%d do not cover %q", match.Start, match.End, match.Pattern)
+		}
+	}
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	m := ahocorasick.NewCaseInsensitive("Bad Word", "ÉCOLE")
+	text := "a BAD word at the école"
+
+	got := m.FindAll(text)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 matches, got %v", got)
+	}
+
+	if got[0].Pattern != "Bad Word" || text[got[0].Start:got[0].End] != "BAD word" {
+		t.Errorf("unexpected first match %+v", got[0])
+	}
+
+	if text[got[1].Start:got[1].End] != "école" {
+		t.Errorf("unexpected second match %+v", got[1])
+	}
+
+	if len(ahocorasick.New("Bad Word").FindAll(text)) != 0 {
+		t.Errorf("the default matcher should be case sensitive")
+	}
+}
+
+func TestCaseFolding(t *testing.T) {
+	// ſ, ς and the Kelvin sign only match through case folding, not lower-casing
+	m := ahocorasick.NewCaseInsensitive("sun", "σοφός", "kilo")
+	text := "ſun, ΣΟΦΌΣ σοφός, \u212Ailo"
+
+	got := m.FindAll(text)
+	if len(got) != 4 {
+		t.Fatalf("expected 4 matches, got %v", got)
+	}
+
+	want := []string{"ſun", "ΣΟΦΌΣ", "σοφός", "\u212Ailo"}
+	for i, w := range want {
+		if text[got[i].Start:got[i].End] != w {
+			t.Errorf("match %d: expected %q, got %q", i, w, text[got[i].Start:got[i].End])
+		}
+	}
+}
+
+func TestFindReader(t *testing.T) {
+	m := ahocorasick.New("spam", "am")
+	text := strings.Repeat("x", 10000) + "spam"
+
+	got := []ahocorasick.Match{}
+	err := m.FindReader(strings.NewReader(text), func(match ahocorasick.Match) bool {
+		got = append(got, match)
+		return true
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 || got[0].Start != 10000 || got[0].End != 10004 || got[1].Start != 10002 {
+		t.Errorf("unexpected matches %v", got)
+	}
+
+	// stop early
+	n := 0
+	m.FindReader(strings.NewReader("spam spam"), func(ahocorasick.Match) bool {
+		n++
+		return false
+	})
+
+	if n != 1 {
+		t.Errorf("FindReader should stop when fn returns false")
+	}
+
+	errRead := errors.New("read failed")
+	if err := m.FindReader(failingReader{errRead}, func(ahocorasick.Match) bool { return true }); err != errRead {
+		t.Errorf("expected the read error, got %v", err)
+	}
+}
+
+// failingReader always returns err.
+type failingReader struct {
+	err error
+}
+
+func (r failingReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
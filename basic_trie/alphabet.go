@@ -0,0 +1,88 @@
+package basic_trie
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// ErrInvalidChar is returned when a word contains a character
+// outside of the trie's alphabet.
+var ErrInvalidChar = errors.New("basic_trie: character not in alphabet")
+
+// Alphabet maps the characters a trie accepts to child indices.
+// Characters are single ASCII bytes.
+type Alphabet struct {
+	chars string
+	index [256]int16 // -1 for bytes outside the alphabet
+}
+
+// Common alphabets.
+var (
+	Lowercase    = MustAlphabet("abcdefghijklmnopqrstuvwxyz")
+	Alphanumeric = MustAlphabet("0123456789abcdefghijklmnopqrstuvwxyz")
+	DNA          = MustAlphabet("ACGT")
+)
+
+// Creates an alphabet of the bytes in chars, in order.
+// Returns an error if chars is empty, contains a byte twice or
+// contains a non-ASCII byte, since words are rebuilt a byte at a time
+// and multi-byte characters would be split.
+func NewAlphabet(chars string) (*Alphabet, error) {
+	if chars == "" {
+		return nil, errors.New("basic_trie: empty alphabet")
+	}
+
+	a := &Alphabet{chars: chars}
+	for i := range a.index {
+		a.index[i] = -1
+	}
+
+	for i := 0; i < len(chars); i++ {
+		if chars[i] >= utf8.RuneSelf {
+			return nil, fmt.Errorf("basic_trie: non-ASCII byte %q in alphabet", chars[i])
+		}
+
+		if a.index[chars[i]] >= 0 {
+			return nil, fmt.Errorf("basic_trie: duplicate character %q in alphabet", chars[i])
+		}
+		a.index[chars[i]] = int16(i)
+	}
+	return a, nil
+}
+
+// Like NewAlphabet but panics on error. Intended for package level variables.
+func MustAlphabet(chars string) *Alphabet {
+	a, err := NewAlphabet(chars)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// Returns the number of characters in the alphabet.
+func (a *Alphabet) Size() int {
+	return len(a.chars)
+}
+
+// Returns the characters of the alphabet.
+func (a *Alphabet) String() string {
+	return a.chars
+}
+
+// returns the child index of c and false if c is not in the alphabet.
+func (a *Alphabet) indexOf(c byte) (int, bool) {
+	i := a.index[c]
+	return int(i), i >= 0
+}
+
+// Validates that every character of word is in the alphabet.
+// The error wraps ErrInvalidChar.
+func (a *Alphabet) Validate(word string) error {
+	for i := 0; i < len(word); i++ {
+		if _, ok := a.indexOf(word[i]); !ok {
+			return fmt.Errorf("%w: %q at position %d", ErrInvalidChar, word[i], i)
+		}
+	}
+	return nil
+}
@@ -1,25 +1,47 @@
 package basic_trie
 
-// The number of possible characters
+import "unsafe"
+
+// The number of possible characters in the default Lowercase alphabet
 const AlphabetSize = 26
 
 type node struct {
-	children [AlphabetSize]*node
+	children []*node // one slot per character of the alphabet
 	terminal bool
 }
 
-type trie struct {
-	root *node
+// Trie is a prefix tree over a fixed alphabet,
+// storing one child pointer per character at every node.
+type Trie struct {
+	root     *node
+	alphabet *Alphabet
+}
+
+// creates a new trie over the Lowercase alphabet with initialized root node.
+func New() *Trie {
+	return NewWithAlphabet(Lowercase)
+}
+
+// creates a new trie over the given alphabet with initialized root node.
+func NewWithAlphabet(alphabet *Alphabet) *Trie {
+	t := &Trie{alphabet: alphabet}
+	t.root = t.newNode()
+	return t
 }
 
-// creates a new trie with initialized root node.
-func New() *trie {
-	return &trie{root: &node{}}
+// Returns the alphabet of the trie.
+func (t *Trie) Alphabet() *Alphabet {
+	return t.alphabet
+}
+
+// allocates a node with a child slot per character.
+func (t *Trie) newNode() *node {
+	return &node{children: make([]*node, t.alphabet.Size())}
 }
 
 // returns true if root is the leaf node.
 func isLastNode(root *node) bool {
-	for i := 0; i < AlphabetSize; i++ {
+	for i := 0; i < len(root.children); i++ {
 		if root.children[i] != nil {
 			return false
 		}
@@ -30,7 +52,7 @@ func isLastNode(root *node) bool {
 // Recursively counts the number of child nodes in root node
 // adding to the count.
 func countNodesRec(root *node, count *int) {
-	for i := 0; i < AlphabetSize; i++ {
+	for i := 0; i < len(root.children); i++ {
 		if root.children[i] != nil {
 			(*count)++
 			countNodesRec(root.children[i], count)
@@ -39,35 +61,43 @@ func countNodesRec(root *node, count *int) {
 }
 
 // Returns the number of nodes in the trie
-func (t *trie) Nodes() int {
+func (t *Trie) Nodes() int {
 	count := 0
 	countNodesRec(t.root, &count)
 	return count
 }
 
-// inserts word into the trie
-func (t *trie) Insert(word string) {
+// inserts word into the trie.
+// Returns an error wrapping ErrInvalidChar if word has a character
+// outside the alphabet, in which case the trie is not modified.
+func (t *Trie) Insert(word string) error {
+	if err := t.alphabet.Validate(word); err != nil {
+		return err
+	}
+
 	wordLen := len(word)
 	currentNode := t.root
 
 	for i := 0; i < wordLen; i++ {
-		charIndex := word[i] - 'a'
+		charIndex, _ := t.alphabet.indexOf(word[i])
 		if currentNode.children[charIndex] == nil {
-			currentNode.children[charIndex] = &node{}
+			currentNode.children[charIndex] = t.newNode()
 		}
 		currentNode = currentNode.children[charIndex]
 	}
 	currentNode.terminal = true
+	return nil
 }
 
-// returns true if word is in the trie
-func (t *trie) Exists(word string) bool {
+// returns true if word is in the trie.
+// Words with characters outside the alphabet are never in the trie.
+func (t *Trie) Exists(word string) bool {
 	wordLen := len(word)
 	currentNode := t.root
 
 	for i := 0; i < wordLen; i++ {
-		charIndex := word[i] - 'a'
-		if currentNode.children[charIndex] == nil {
+		charIndex, ok := t.alphabet.indexOf(word[i])
+		if !ok || currentNode.children[charIndex] == nil {
 			return false
 		}
 		currentNode = currentNode.children[charIndex]
@@ -77,29 +107,29 @@ func (t *trie) Exists(word string) bool {
 
 // recursively searches for words in child nodes of triNode
 // and appends a found word to words slice.
-func suggestionsRec(triNode *node, prefix string, words *[]string) {
+func (t *Trie) suggestionsRec(triNode *node, prefix string, words *[]string) {
 	if triNode.terminal {
 		*words = append(*words, prefix)
 	}
 
-	for i := 0; i < AlphabetSize; i++ {
+	for i := 0; i < len(triNode.children); i++ {
 		if triNode.children[i] != nil {
 			// child node character value
-			child := rune('a' + i)
-			suggestionsRec(triNode.children[i], prefix+string(child), words)
+			child := t.alphabet.chars[i]
+			t.suggestionsRec(triNode.children[i], prefix+string(child), words)
 		}
 	}
 }
 
 // returns a slice of words with a prefix query from node.
-func (t *trie) suggestions(node *node, query string) []string {
+func (t *Trie) suggestions(root *node, query string) []string {
 	words := []string{}
 	wordLen := len(query)
-	currentNode := t.root
+	currentNode := root
 
 	for i := 0; i < wordLen; i++ {
-		charIndex := query[i] - 'a'
-		if currentNode.children[charIndex] == nil {
+		charIndex, ok := t.alphabet.indexOf(query[i])
+		if !ok || currentNode.children[charIndex] == nil {
 			return words
 		}
 		currentNode = currentNode.children[charIndex]
@@ -110,12 +140,12 @@ func (t *trie) suggestions(node *node, query string) []string {
 		return words
 	}
 
-	suggestionsRec(currentNode, query, &words)
+	t.suggestionsRec(currentNode, query, &words)
 	return words
 }
 
 // Returns a slice of words starting with prefix query.
-func (t *trie) GetAutoSuggestions(query string) []string {
+func (t *Trie) GetAutoSuggestions(query string) []string {
 	if t.root == nil {
 		return []string{}
 	}
@@ -123,28 +153,96 @@ func (t *trie) GetAutoSuggestions(query string) []string {
 	return t.suggestions(t.root, query)
 }
 
-func (t *trie) sizeRec(root *node, wordCount *int, prefix string) {
+func (t *Trie) sizeRec(root *node, wordCount *int, prefix string) {
 	if root.terminal {
 		(*wordCount)++
 	}
 
-	for i := 0; i < AlphabetSize; i++ {
+	for i := 0; i < len(root.children); i++ {
 		if root.children[i] != nil {
-			child := rune('a' + i)
+			child := t.alphabet.chars[i]
 			t.sizeRec(root.children[i], wordCount, prefix+string(child))
 		}
 	}
 }
 
 // Returns the number of words in the trie.
-func (t *trie) Size() int {
+func (t *Trie) Size() int {
 	n := 0
 	t.sizeRec(t.root, &n, "")
 	return n
 }
 
-func (t *trie) Memory() int {
+// Returns the memory used by the nodes of the trie in bytes.
+func (t *Trie) Memory() int {
 	padding := 7 // 7 bytes of pading after the bool
-	nodeSize := (8 * AlphabetSize) + 1 + padding
+	header := int(unsafe.Sizeof([]*node{}))
+	nodeSize := header + 1 + padding + (8 * t.alphabet.Size())
 	return t.Nodes() * nodeSize
 }
+
+// returns the path of nodes from the root to the node for word
+// and the child indices taken, or nil if word is not a path in the trie.
+func (t *Trie) path(word string) ([]*node, []int) {
+	nodes := []*node{t.root}
+	indices := make([]int, 0, len(word))
+	currentNode := t.root
+
+	for i := 0; i < len(word); i++ {
+		charIndex, ok := t.alphabet.indexOf(word[i])
+		if !ok || currentNode.children[charIndex] == nil {
+			return nil, nil
+		}
+		currentNode = currentNode.children[charIndex]
+		nodes = append(nodes, currentNode)
+		indices = append(indices, charIndex)
+	}
+	return nodes, indices
+}
+
+// removes nodes from the end of path that neither end a word nor have children.
+// indices[i] is the child index from nodes[i] to nodes[i+1].
+func prune(nodes []*node, indices []int) {
+	for i := len(nodes) - 1; i > 0; i-- {
+		if nodes[i].terminal || !isLastNode(nodes[i]) {
+			return
+		}
+		nodes[i-1].children[indices[i-1]] = nil
+	}
+}
+
+// removes word from the trie, pruning branches left without words.
+// Returns false if word was not in the trie.
+func (t *Trie) Delete(word string) bool {
+	nodes, indices := t.path(word)
+	if nodes == nil || !nodes[len(nodes)-1].terminal {
+		return false
+	}
+
+	nodes[len(nodes)-1].terminal = false
+	prune(nodes, indices)
+	return true
+}
+
+// removes every word starting with prefix, including prefix itself.
+// Returns the number of words removed.
+func (t *Trie) DeletePrefix(prefix string) int {
+	nodes, indices := t.path(prefix)
+	if nodes == nil {
+		return 0
+	}
+
+	n := 0
+	t.sizeRec(nodes[len(nodes)-1], &n, prefix)
+
+	if len(indices) == 0 {
+		t.root = t.newNode()
+		return n
+	}
+
+	// detach the subtree, then prune its now empty ancestors
+	last := len(indices) - 1
+	nodes[last].children[indices[last]] = nil
+	prune(nodes[:last+1], indices[:last])
+	return n
+}
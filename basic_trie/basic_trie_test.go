@@ -0,0 +1,252 @@
+package basic_trie_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/abiiranathan/algo/basic_trie"
+)
+
+func TestTrie(t *testing.T) {
+	tr := basic_trie.New()
+
+	for _, w := range []string{"car", "cart", "carton", "cat", "dog"} {
+		if err := tr.Insert(w); err != nil {
+			t.Fatalf("Insert(%q) returned an error: %v", w, err)
+		}
+	}
+
+	if tr.Size() != 5 || tr.Nodes() != 10 {
+		t.Errorf("expected 5 words in 10 nodes, got %d in %d", tr.Size(), tr.Nodes())
+	}
+
+	if !tr.Exists("cart") || tr.Exists("ca") {
+		t.Errorf("unexpected membership")
+	}
+
+	if got := tr.GetAutoSuggestions("car"); len(got) != 3 || got[0] != "car" {
+		t.Errorf("unexpected suggestions: %v", got)
+	}
+
+	if !tr.Delete("cart") || tr.Delete("cart") || tr.Nodes() != 10 {
+		t.Errorf("Delete should keep nodes still on the path to carton")
+	}
+
+	tr.Delete("carton")
+	if tr.Nodes() != 7 {
+		t.Errorf("expected 7 nodes after pruning, got %d", tr.Nodes())
+	}
+
+	if tr.DeletePrefix("ca") != 2 || tr.Nodes() != 3 {
+		t.Errorf("DeletePrefix should remove car and cat")
+	}
+
+	if tr.Memory() != 3*(24+8+8*basic_trie.AlphabetSize) {
+		t.Errorf("unexpected memory estimate %d", tr.Memory())
+	}
+}
+
+func TestTrieDelete(t *testing.T) {
+	tr := basic_trie.New()
+	for _, w := range []string{"car", "cart", "cat", "dog"} {
+		tr.Insert(w)
+	}
+
+	// neither missing words nor prefixes of words can be deleted
+	if tr.Delete("cow") || tr.Delete("ca") || tr.Delete("carts") || tr.Delete("") {
+		t.Errorf("Delete should only remove stored words")
+	}
+
+	if !tr.Delete("car") || tr.Exists("car") || !tr.Exists("cart") {
+		t.Errorf("deleting car should keep cart")
+	}
+
+	// c-a-r-t, c-a-t and d-o-g share the c-a nodes
+	if tr.Nodes() != 8 {
+		t.Errorf("expected 8 nodes, got %d", tr.Nodes())
+	}
+
+	if !tr.Delete("cart") || tr.Nodes() != 6 {
+		t.Errorf("deleting cart should prune r-t, got %d nodes", tr.Nodes())
+	}
+
+	if tr.DeletePrefix("x") != 0 || tr.DeletePrefix("dogs") != 0 {
+		t.Errorf("DeletePrefix of a missing prefix should remove nothing")
+	}
+
+	if tr.DeletePrefix("do") != 1 || tr.Exists("dog") || tr.Nodes() != 3 {
+		t.Errorf("DeletePrefix should remove dog and prune d-o-g")
+	}
+
+	if tr.DeletePrefix("") != 1 || tr.Size() != 0 || tr.Nodes() != 0 {
+		t.Errorf("empty prefix should clear the trie")
+	}
+
+	// the trie is still usable after being cleared
+	tr.Insert("cat")
+	if !tr.Exists("cat") || tr.Size() != 1 {
+		t.Errorf("insert after clearing failed")
+	}
+}
+
+func TestTrieInvalidChars(t *testing.T) {
+	tr := basic_trie.New()
+
+	for _, w := range []string{"Cat", "c4t", "c t", "café"} {
+		err := tr.Insert(w)
+		if !errors.Is(err, basic_trie.ErrInvalidChar) {
+			t.Errorf("Insert(%q) should fail with ErrInvalidChar, got %v", w, err)
+		}
+
+		if tr.Exists(w) || len(tr.GetAutoSuggestions(w)) != 0 || tr.Delete(w) || tr.DeletePrefix(w) != 0 {
+			t.Errorf("lookups of %q should not panic or match", w)
+		}
+	}
+
+	if tr.Size() != 0 || tr.Nodes() != 0 {
+		t.Errorf("failed inserts should not modify the trie")
+	}
+}
+
+func TestTrieAlphabets(t *testing.T) {
+	dna := basic_trie.NewWithAlphabet(basic_trie.DNA)
+	if err := dna.Insert("GATTACA"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dna.Insert("gattaca"); !errors.Is(err, basic_trie.ErrInvalidChar) {
+		t.Errorf("DNA alphabet should reject lowercase, got %v", err)
+	}
+
+	if got := dna.GetAutoSuggestions("GAT"); len(got) != 1 || got[0] != "GATTACA" {
+		t.Errorf("unexpected suggestions: %v", got)
+	}
+
+	an := basic_trie.NewWithAlphabet(basic_trie.Alphanumeric)
+	if err := an.Insert("route66"); err != nil || !an.Exists("route66") {
+		t.Errorf("alphanumeric alphabet should accept digits")
+	}
+
+	if _, err := basic_trie.NewAlphabet("abca"); err == nil {
+		t.Errorf("duplicate characters should be rejected")
+	}
+
+	// multi-byte characters would be split when rebuilding words
+	if _, err := basic_trie.NewAlphabet("éa"); err == nil {
+		t.Errorf("non-ASCII alphabets should be rejected")
+	}
+
+	if _, err := basic_trie.NewAlphabet(""); err == nil {
+		t.Errorf("an empty alphabet should be rejected")
+	}
+
+	if basic_trie.DNA.Size() != 4 || basic_trie.DNA.String() != "ACGT" {
+		t.Errorf("unexpected DNA alphabet")
+	}
+}
+
+func TestTriePersistence(t *testing.T) {
+	tr := basic_trie.NewWithAlphabet(basic_trie.DNA)
+	tr.Insert("GATTACA")
+	tr.Insert("GATC")
+
+	var buf bytes.Buffer
+	n, err := tr.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo reported %d bytes, wrote %d", n, buf.Len())
+	}
+
+	loaded := basic_trie.New()
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Alphabet().String() != "ACGT" || loaded.Size() != 2 || !loaded.Exists("GATC") {
+		t.Errorf("loaded trie does not match")
+	}
+
+	if _, err := loaded.ReadFrom(strings.NewReader("BTRI\x01\x01a\x00\x01\x05")); err != basic_trie.ErrBadFormat {
+		t.Errorf("expected ErrBadFormat, got %v", err)
+	}
+
+	words, err := basic_trie.LoadWords(strings.NewReader("apple\r\nbanana\n\ncherry\n"), basic_trie.Lowercase)
+	if err != nil || words.Size() != 3 || !words.Exists("apple") {
+		t.Errorf("LoadWords failed: %v", err)
+	}
+
+	_, err = basic_trie.LoadWords(strings.NewReader("apple\nBanana\n"), basic_trie.Lowercase)
+	if !errors.Is(err, basic_trie.ErrInvalidChar) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an invalid character error on line 2, got %v", err)
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	words := []string{"car", "cart", "carton", "cat", "dog", "do"}
+
+	b := basic_trie.NewBuilder(basic_trie.Lowercase)
+	for _, w := range words {
+		if err := b.Add(w); err != nil {
+			t.Fatalf("Add(%q) returned an error: %v", w, err)
+		}
+	}
+	b.Add("cat")
+
+	if err := b.Add("Cat"); !errors.Is(err, basic_trie.ErrInvalidChar) {
+		t.Errorf("expected ErrInvalidChar, got %v", err)
+	}
+
+	built := b.Trie()
+	inserted := basic_trie.New()
+	for _, w := range words {
+		inserted.Insert(w)
+	}
+
+	if built.Size() != 6 || built.Nodes() != inserted.Nodes() {
+		t.Errorf("expected %d words in %d nodes, got %d in %d", 6, inserted.Nodes(), built.Size(), built.Nodes())
+	}
+
+	for _, w := range words {
+		if !built.Exists(w) {
+			t.Errorf("expected %q to exist", w)
+		}
+	}
+
+	if built.Exists("ca") || built.Exists("Cat") {
+		t.Errorf("unexpected membership")
+	}
+
+	// unsorted lists still load correctly
+	unsorted, err := basic_trie.LoadWords(strings.NewReader("dog\ncat\ndo\ncar\n"), basic_trie.Lowercase)
+	if err != nil || unsorted.Size() != 4 || !unsorted.Exists("do") || !unsorted.Exists("car") {
+		t.Errorf("LoadWords failed on unsorted input: %v", err)
+	}
+}
+
+func BenchmarkLoadWords(b *testing.B) {
+	var sb strings.Builder
+	for i := 0; i < 20000; i++ {
+		word := []byte{}
+		for n := i; ; n /= 26 {
+			word = append(word, byte('a'+n%26))
+			if n < 26 {
+				break
+			}
+		}
+		sb.Write(word)
+		sb.WriteByte('\n')
+	}
+	list := sb.String()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := basic_trie.LoadWords(strings.NewReader(list), basic_trie.Lowercase); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
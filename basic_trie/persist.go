@@ -0,0 +1,207 @@
+package basic_trie
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/abiiranathan/algo/internal/countio"
+)
+
+// ErrBadFormat is returned by ReadFrom when the input is not a serialized trie.
+var ErrBadFormat = errors.New("basic_trie: invalid serialized trie")
+
+// magic header of the binary format, followed by a version byte.
+const magic = "BTRI\x01"
+
+// WriteTo writes the trie and its alphabet to w in a compact binary format.
+// Each node is written as its terminal flag, its number of children and,
+// for each child, the child index followed by the child node.
+//
+// Implements io.WriterTo.
+func (t *Trie) WriteTo(w io.Writer) (int64, error) {
+	cw := countio.NewWriter(w)
+	bw := bufio.NewWriter(cw)
+	var buf [binary.MaxVarintLen64]byte
+
+	bw.WriteString(magic)
+	bw.Write(buf[:binary.PutUvarint(buf[:], uint64(t.alphabet.Size()))])
+	bw.WriteString(t.alphabet.chars)
+	writeNode(bw, t.root)
+
+	err := bw.Flush()
+	return cw.Count(), err
+}
+
+// writes root and its subtree to w.
+func writeNode(w *bufio.Writer, root *node) {
+	var buf [binary.MaxVarintLen64]byte
+
+	if root.terminal {
+		w.WriteByte(1)
+	} else {
+		w.WriteByte(0)
+	}
+
+	n := 0
+	for _, child := range root.children {
+		if child != nil {
+			n++
+		}
+	}
+
+	w.Write(buf[:binary.PutUvarint(buf[:], uint64(n))])
+	for i, child := range root.children {
+		if child != nil {
+			w.Write(buf[:binary.PutUvarint(buf[:], uint64(i))])
+			writeNode(w, child)
+		}
+	}
+}
+
+// ReadFrom replaces the trie, including its alphabet,
+// with a trie previously written by WriteTo.
+//
+// Implements io.ReaderFrom.
+func (t *Trie) ReadFrom(r io.Reader) (int64, error) {
+	cr := countio.NewReader(r)
+
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(cr, header); err != nil || string(header) != magic {
+		return cr.Count(), ErrBadFormat
+	}
+
+	size, err := binary.ReadUvarint(cr)
+	if err != nil || size == 0 || size > 256 {
+		return cr.Count(), ErrBadFormat
+	}
+
+	chars := make([]byte, size)
+	if _, err := io.ReadFull(cr, chars); err != nil {
+		return cr.Count(), ErrBadFormat
+	}
+
+	alphabet, err := NewAlphabet(string(chars))
+	if err != nil {
+		return cr.Count(), ErrBadFormat
+	}
+
+	loaded := &Trie{alphabet: alphabet}
+	if loaded.root, err = loaded.readNode(cr); err != nil {
+		return cr.Count(), err
+	}
+
+	*t = *loaded
+	return cr.Count(), nil
+}
+
+// reads a node and its subtree.
+func (t *Trie) readNode(r *countio.Reader) (*node, error) {
+	n := t.newNode()
+
+	flag, err := r.ReadByte()
+	if err != nil || flag > 1 {
+		return nil, ErrBadFormat
+	}
+	n.terminal = flag == 1
+
+	count, err := binary.ReadUvarint(r)
+	if err != nil || count > uint64(len(n.children)) {
+		return nil, ErrBadFormat
+	}
+
+	for i := uint64(0); i < count; i++ {
+		index, err := binary.ReadUvarint(r)
+		if err != nil || index >= uint64(len(n.children)) || n.children[index] != nil {
+			return nil, ErrBadFormat
+		}
+
+		if n.children[index], err = t.readNode(r); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// Builder constructs a Trie from a list of words, typically sorted.
+//
+// It keeps the path to the previous word, so each word only walks
+// down from where it diverges from the previous one instead of
+// from the root. Sorted input shares the longest prefixes; words in
+// any other order are still inserted correctly.
+type Builder struct {
+	trie *Trie
+	path []*node // path[i] is the node after i characters of prev
+	prev string
+}
+
+// Creates a new Builder for a trie over alphabet.
+func NewBuilder(alphabet *Alphabet) *Builder {
+	t := NewWithAlphabet(alphabet)
+	return &Builder{trie: t, path: []*node{t.root}}
+}
+
+// Adds word to the trie. Duplicates are ignored.
+// Returns an error wrapping ErrInvalidChar if word has a character
+// outside the alphabet, in which case the trie is not modified.
+func (b *Builder) Add(word string) error {
+	if err := b.trie.alphabet.Validate(word); err != nil {
+		return err
+	}
+
+	// length of the common prefix with the previous word
+	l := 0
+	for l < len(word) && l < len(b.prev) && word[l] == b.prev[l] {
+		l++
+	}
+
+	b.path = b.path[:l+1]
+	n := b.path[l]
+	for i := l; i < len(word); i++ {
+		charIndex, _ := b.trie.alphabet.indexOf(word[i])
+		if n.children[charIndex] == nil {
+			n.children[charIndex] = b.trie.newNode()
+		}
+		n = n.children[charIndex]
+		b.path = append(b.path, n)
+	}
+
+	n.terminal = true
+	b.prev = word
+	return nil
+}
+
+// Returns the trie built so far. The Builder must not be used afterwards.
+func (b *Builder) Trie() *Trie {
+	return b.trie
+}
+
+// Loads a newline separated word list into a new trie over alphabet.
+// Empty lines are skipped and a trailing carriage return is removed.
+// Words are added with a Builder, so sorted input loads fastest.
+//
+// Returns an error wrapping ErrInvalidChar, with the line number,
+// if a word has a character outside the alphabet.
+func LoadWords(r io.Reader, alphabet *Alphabet) (*Trie, error) {
+	b := NewBuilder(alphabet)
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		word := strings.TrimSuffix(scanner.Text(), "\r")
+		if word == "" {
+			continue
+		}
+
+		if err := b.Add(word); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b.Trie(), nil
+}
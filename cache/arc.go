@@ -0,0 +1,237 @@
+package cache
+
+import "container/list"
+
+// arcEntry is an entry in one of the four ARC lists.
+// Entries in the ghost lists b1 and b2 hold no value.
+type arcEntry[K comparable, V any] struct {
+	key   K
+	value V
+	ll    *list.List // the list holding this entry
+}
+
+// ARC is an Adaptive Replacement Cache.
+//
+// It balances recency (t1, entries seen once) against frequency
+// (t2, entries seen at least twice) by tracking recently evicted keys
+// in the ghost lists b1 and b2 and adapting the target size p of t1
+// whenever a ghost key is requested again.
+type ARC[K comparable, V any] struct {
+	common[K, V]
+	p              int // target size of t1
+	t1, t2, b1, b2 *list.List
+	items          map[K]*list.Element
+}
+
+var _ Cache[int, int] = (*ARC[int, int])(nil)
+
+// Creates a new ARC cache holding at most capacity entries.
+// Panics if capacity <= 0.
+func NewARC[K comparable, V any](capacity int) *ARC[K, V] {
+	return &ARC[K, V]{
+		common: newCommon[K, V](capacity),
+		t1:     list.New(),
+		t2:     list.New(),
+		b1:     list.New(),
+		b2:     list.New(),
+		items:  make(map[K]*list.Element),
+	}
+}
+
+// returns the resident element under key or nil.
+func (c *ARC[K, V]) resident(key K) *list.Element {
+	e, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+
+	if ll := e.Value.(*arcEntry[K, V]).ll; ll == c.t1 || ll == c.t2 {
+		return e
+	}
+	return nil
+}
+
+// moves e to the front of ll.
+func (c *ARC[K, V]) move(e *list.Element, ll *list.List) *list.Element {
+	ent := e.Value.(*arcEntry[K, V])
+	ent.ll.Remove(e)
+	ent.ll = ll
+
+	e = ll.PushFront(ent)
+	c.items[ent.key] = e
+	return e
+}
+
+// drops the least recently used entry of a ghost list.
+func (c *ARC[K, V]) dropGhost(ll *list.List) {
+	ent := ll.Remove(ll.Back()).(*arcEntry[K, V])
+	delete(c.items, ent.key)
+}
+
+// replace evicts a resident entry into the matching ghost list.
+// inB2 is true when the requested key was found in b2.
+func (c *ARC[K, V]) replace(inB2 bool) {
+	from, to := c.t2, c.b2
+	if n := c.t1.Len(); n > 0 && (n > c.p || (inB2 && n == c.p) || c.t2.Len() == 0) {
+		from, to = c.t1, c.b1
+	}
+
+	e := from.Back()
+	ent := e.Value.(*arcEntry[K, V])
+	c.evict(ent.key, ent.value)
+
+	var zero V
+	ent.value = zero
+	c.move(e, to)
+}
+
+// Returns the value under key and promotes it to the frequently used list.
+func (c *ARC[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.resident(key)
+	if e == nil {
+		c.stats.Misses++
+		return value, false
+	}
+
+	c.stats.Hits++
+	e = c.move(e, c.t2)
+	return e.Value.(*arcEntry[K, V]).value, true
+}
+
+// Returns the value under key without updating the lists.
+func (c *ARC[K, V]) Peek(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.resident(key)
+	if e == nil {
+		return value, false
+	}
+	return e.Value.(*arcEntry[K, V]).value, true
+}
+
+// Inserts value under key, evicting an entry if the cache is full.
+func (c *ARC[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.unlock()
+
+	full := c.t1.Len()+c.t2.Len() >= c.capacity
+
+	if e, ok := c.items[key]; ok {
+		ent := e.Value.(*arcEntry[K, V])
+
+		switch ent.ll {
+		case c.t1, c.t2:
+			ent.value = value
+			c.move(e, c.t2)
+			return
+		case c.b1:
+			// recency was undervalued: grow t1
+			c.p += max(c.b2.Len()/c.b1.Len(), 1)
+			c.p = min(c.p, c.capacity)
+			if full {
+				c.replace(false)
+			}
+		case c.b2:
+			// frequency was undervalued: shrink t1
+			c.p -= max(c.b1.Len()/c.b2.Len(), 1)
+			c.p = max(c.p, 0)
+			if full {
+				c.replace(true)
+			}
+		}
+
+		ent.value = value
+		c.move(e, c.t2)
+		return
+	}
+
+	total := c.t1.Len() + c.t2.Len() + c.b1.Len() + c.b2.Len()
+	if c.t1.Len()+c.b1.Len() >= c.capacity {
+		if c.t1.Len() < c.capacity {
+			c.dropGhost(c.b1)
+			if full {
+				c.replace(false)
+			}
+		} else {
+			// b1 is empty so evict straight from t1
+			e := c.t1.Back()
+			ent := c.t1.Remove(e).(*arcEntry[K, V])
+			delete(c.items, ent.key)
+			c.evict(ent.key, ent.value)
+		}
+	} else if total >= c.capacity {
+		if total >= 2*c.capacity {
+			c.dropGhost(c.b2)
+		}
+		if full {
+			c.replace(false)
+		}
+	}
+
+	c.items[key] = c.t1.PushFront(&arcEntry[K, V]{key: key, value: value, ll: c.t1})
+}
+
+// Removes key from the cache. Returns true if it was present.
+// Ghost entries for key are forgotten as well.
+func (c *ARC[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+
+	ent := e.Value.(*arcEntry[K, V])
+	ent.ll.Remove(e)
+	delete(c.items, key)
+	return ent.ll == c.t1 || ent.ll == c.t2
+}
+
+// Returns true if key is in the cache.
+func (c *ARC[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.resident(key) != nil
+}
+
+// Returns the keys in the cache, recently used entries first
+// followed by frequently used ones.
+func (c *ARC[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]K, 0, c.t1.Len()+c.t2.Len())
+	for _, ll := range []*list.List{c.t1, c.t2} {
+		for e := ll.Front(); e != nil; e = e.Next() {
+			keys = append(keys, e.Value.(*arcEntry[K, V]).key)
+		}
+	}
+	return keys
+}
+
+// Returns the number of entries in the cache.
+func (c *ARC[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.t1.Len() + c.t2.Len()
+}
+
+// Removes all entries from the cache and resets the adaptation state.
+func (c *ARC[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.t1.Init()
+	c.t2.Init()
+	c.b1.Init()
+	c.b2.Init()
+	c.items = make(map[K]*list.Element)
+	c.p = 0
+}
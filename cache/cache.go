@@ -0,0 +1,115 @@
+// Package cache implements size-bounded caches with different eviction
+// policies. All caches are safe for concurrent use.
+package cache
+
+import "sync"
+
+// Cache is the interface shared by the LRU, LFU and ARC caches.
+type Cache[K comparable, V any] interface {
+	// Returns the value under key and true if present, updating
+	// the usage information used by the eviction policy.
+	Get(key K) (V, bool)
+
+	// Returns the value under key without updating usage information or stats.
+	Peek(key K) (V, bool)
+
+	// Inserts value under key, evicting an entry if the cache is full.
+	Set(key K, value V)
+
+	// Removes key from the cache. Returns true if it was present.
+	Delete(key K) bool
+
+	// Returns true if key is in the cache. Does not update usage information.
+	Contains(key K) bool
+
+	// Returns the keys in the cache.
+	Keys() []K
+
+	// Returns the number of entries in the cache.
+	Len() int
+
+	// Returns the maximum number of entries in the cache.
+	Cap() int
+
+	// Removes all entries from the cache. Stats are preserved.
+	Clear()
+
+	// Returns a copy of the hit, miss and eviction counters.
+	Stats() Stats
+
+	// Registers fn to be called with every entry evicted to make room for another.
+	// It is not called for Delete or Clear.
+	OnEvict(fn func(key K, value V))
+}
+
+// Stats holds cache counters.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// entry is a key-value pair stored in a cache.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// common holds the state shared by all cache implementations.
+type common[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	stats    Stats
+	onEvict  func(key K, value V)
+	evicted  []entry[K, V] // entries awaiting the onEvict callback
+}
+
+// panics if capacity is not positive
+func newCommon[K comparable, V any](capacity int) common[K, V] {
+	if capacity <= 0 {
+		panic("cache: capacity must be greater than zero")
+	}
+	return common[K, V]{capacity: capacity}
+}
+
+// Returns the maximum number of entries in the cache.
+func (c *common[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Returns a copy of the hit, miss and eviction counters.
+func (c *common[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+// Registers fn to be called with every entry evicted to make room for another.
+// fn is called after the lock is released so it may access the cache.
+func (c *common[K, V]) OnEvict(fn func(key K, value V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onEvict = fn
+}
+
+// records an eviction. Must be called with the lock held.
+func (c *common[K, V]) evict(key K, value V) {
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.evicted = append(c.evicted, entry[K, V]{key, value})
+	}
+}
+
+// unlock releases the lock and then runs the eviction callback
+// for entries evicted while it was held.
+func (c *common[K, V]) unlock() {
+	evicted, fn := c.evicted, c.onEvict
+	c.evicted = nil
+	c.mu.Unlock()
+
+	for _, e := range evicted {
+		fn(e.key, e.value)
+	}
+}
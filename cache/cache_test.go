@@ -0,0 +1,194 @@
+package cache_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/abiiranathan/algo/cache"
+)
+
+// constructors for every cache implementation
+var caches = map[string]func(capacity int) cache.Cache[int, int]{
+	"LRU": func(capacity int) cache.Cache[int, int] { return cache.NewLRU[int, int](capacity) },
+	"LFU": func(capacity int) cache.Cache[int, int] { return cache.NewLFU[int, int](capacity) },
+	"ARC": func(capacity int) cache.Cache[int, int] { return cache.NewARC[int, int](capacity) },
+}
+
+func TestCacheCommon(t *testing.T) {
+	t.Parallel()
+
+	for name, newCache := range caches {
+		c := newCache(3)
+		evicted := 0
+		c.OnEvict(func(key, value int) {
+			evicted++
+		})
+
+		c.Set(1, 10)
+		c.Set(2, 20)
+		c.Set(3, 30)
+
+		if v, ok := c.Get(1); !ok || v != 10 {
+			t.Errorf("%s: Get(1) should return 10", name)
+		}
+
+		if _, ok := c.Get(100); ok {
+			t.Errorf("%s: Get on a missing key should return false", name)
+		}
+
+		c.Set(4, 40)
+		if c.Len() != 3 || c.Cap() != 3 || len(c.Keys()) != 3 {
+			t.Errorf("%s: cache should stay within its capacity", name)
+		}
+
+		stats := c.Stats()
+		if stats.Hits != 1 || stats.Misses != 1 || stats.Evictions != 1 || evicted != 1 {
+			t.Errorf("%s: unexpected stats %+v, evicted %d", name, stats, evicted)
+		}
+
+		if !c.Contains(4) {
+			t.Errorf("%s: newest key should be in the cache", name)
+		}
+
+		if v, ok := c.Peek(4); !ok || v != 40 {
+			t.Errorf("%s: Peek(4) should return 40", name)
+		}
+
+		if !c.Delete(4) || c.Delete(4) || c.Contains(4) {
+			t.Errorf("%s: Delete failed", name)
+		}
+
+		c.Clear()
+		if c.Len() != 0 {
+			t.Errorf("%s: Clear should empty the cache", name)
+		}
+	}
+}
+
+func TestLRUEviction(t *testing.T) {
+	t.Parallel()
+	c := cache.NewLRU[string, int](2)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Set("c", 3)
+
+	if c.Contains("b") || !c.Contains("a") {
+		t.Error("LRU should evict the least recently used key")
+	}
+
+	keys := c.Keys()
+	if keys[0] != "c" || keys[1] != "a" {
+		t.Errorf("Keys should be ordered by recency, got %v", keys)
+	}
+}
+
+func TestLFUEviction(t *testing.T) {
+	t.Parallel()
+	c := cache.NewLFU[string, int](2)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	c.Set("c", 3)
+
+	if c.Contains("b") || !c.Contains("a") {
+		t.Error("LFU should evict the least frequently used key")
+	}
+
+	if c.Frequency("a") != 3 || c.Frequency("c") != 1 {
+		t.Errorf("unexpected frequencies a=%d c=%d", c.Frequency("a"), c.Frequency("c"))
+	}
+
+	// ties are broken by recency
+	c.Set("d", 4)
+	if c.Contains("c") {
+		t.Error("LFU should evict c, the only key used once")
+	}
+
+	c.Delete("a")
+	c.Set("e", 5)
+	c.Set("f", 6)
+	if c.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", c.Len())
+	}
+}
+
+func TestARCScanResistance(t *testing.T) {
+	t.Parallel()
+	c := cache.NewARC[int, int](4)
+
+	// frequently used keys
+	for i := 0; i < 3; i++ {
+		c.Set(1, 1)
+		c.Set(2, 2)
+		c.Get(1)
+		c.Get(2)
+	}
+
+	// a one-off scan should not flush them
+	for i := 100; i < 120; i++ {
+		c.Set(i, i)
+	}
+
+	if !c.Contains(1) || !c.Contains(2) {
+		t.Error("ARC should keep frequently used keys during a scan")
+	}
+}
+
+func TestCacheRandomized(t *testing.T) {
+	t.Parallel()
+
+	for name, newCache := range caches {
+		c := newCache(16)
+		r := rand.New(rand.NewSource(1))
+
+		for i := 0; i < 10000; i++ {
+			key := r.Intn(64)
+			switch r.Intn(4) {
+			case 0:
+				c.Delete(key)
+			case 1:
+				if v, ok := c.Get(key); ok && v != key {
+					t.Fatalf("%s: Get(%d) returned %d", name, key, v)
+				}
+			default:
+				c.Set(key, key)
+			}
+
+			if c.Len() > 16 || len(c.Keys()) != c.Len() {
+				t.Fatalf("%s: cache exceeded its capacity", name)
+			}
+		}
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	t.Parallel()
+
+	for name, newCache := range caches {
+		c := newCache(32)
+
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 500; i++ {
+					c.Set(g*1000+i%50, i)
+					c.Get(g*1000 + i%40)
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		stats := c.Stats()
+		if stats.Hits+stats.Misses != 8*500 {
+			t.Errorf("%s: expected %d lookups, got %d", name, 8*500, stats.Hits+stats.Misses)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package cache
+
+import "container/list"
+
+// lfuEntry is an entry in the LFU cache with its access frequency.
+type lfuEntry[K comparable, V any] struct {
+	key   K
+	value V
+	freq  int
+}
+
+// LFU is a cache that evicts the least frequently used entry.
+// Ties are broken by evicting the least recently used of those entries.
+//
+// All operations are O(1).
+type LFU[K comparable, V any] struct {
+	common[K, V]
+	items   map[K]*list.Element
+	freqs   map[int]*list.List // entries per frequency, front is most recent
+	minFreq int
+}
+
+var _ Cache[int, int] = (*LFU[int, int])(nil)
+
+// Creates a new LFU cache holding at most capacity entries.
+// Panics if capacity <= 0.
+func NewLFU[K comparable, V any](capacity int) *LFU[K, V] {
+	return &LFU[K, V]{
+		common: newCommon[K, V](capacity),
+		items:  make(map[K]*list.Element),
+		freqs:  make(map[int]*list.List),
+	}
+}
+
+// moves e to the list of the next frequency.
+// Must be called with the lock held.
+func (c *LFU[K, V]) touch(e *list.Element) *list.Element {
+	ent := e.Value.(*lfuEntry[K, V])
+	c.unlink(e)
+
+	ent.freq++
+	return c.link(ent)
+}
+
+// adds ent to the front of the list for its frequency.
+func (c *LFU[K, V]) link(ent *lfuEntry[K, V]) *list.Element {
+	l, ok := c.freqs[ent.freq]
+	if !ok {
+		l = list.New()
+		c.freqs[ent.freq] = l
+	}
+
+	e := l.PushFront(ent)
+	c.items[ent.key] = e
+	return e
+}
+
+// removes e from the list of its frequency, dropping the list when empty.
+func (c *LFU[K, V]) unlink(e *list.Element) {
+	ent := e.Value.(*lfuEntry[K, V])
+	l := c.freqs[ent.freq]
+	l.Remove(e)
+	delete(c.items, ent.key)
+
+	if l.Len() == 0 {
+		delete(c.freqs, ent.freq)
+		if c.minFreq == ent.freq {
+			c.minFreq++
+		}
+	}
+}
+
+// Returns the value under key and increments its frequency.
+func (c *LFU[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return value, false
+	}
+
+	c.stats.Hits++
+	e = c.touch(e)
+	return e.Value.(*lfuEntry[K, V]).value, true
+}
+
+// Returns the value under key without incrementing its frequency.
+func (c *LFU[K, V]) Peek(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return value, false
+	}
+	return e.Value.(*lfuEntry[K, V]).value, true
+}
+
+// Inserts value under key, evicting the least frequently used entry if full.
+// Updating an existing key counts as a use.
+func (c *LFU[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.unlock()
+
+	if e, ok := c.items[key]; ok {
+		e.Value.(*lfuEntry[K, V]).value = value
+		c.touch(e)
+		return
+	}
+
+	if len(c.items) >= c.capacity {
+		// minFreq may be stale after Delete
+		if _, ok := c.freqs[c.minFreq]; !ok {
+			c.minFreq = c.lowestFreq()
+		}
+
+		victim := c.freqs[c.minFreq].Back()
+		ent := victim.Value.(*lfuEntry[K, V])
+		c.unlink(victim)
+		c.evict(ent.key, ent.value)
+	}
+
+	c.link(&lfuEntry[K, V]{key: key, value: value, freq: 1})
+	c.minFreq = 1
+}
+
+// returns the lowest frequency in use.
+func (c *LFU[K, V]) lowestFreq() int {
+	lowest := 0
+	for f := range c.freqs {
+		if lowest == 0 || f < lowest {
+			lowest = f
+		}
+	}
+	return lowest
+}
+
+// Removes key from the cache. Returns true if it was present.
+func (c *LFU[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.unlink(e)
+	return true
+}
+
+// Returns true if key is in the cache.
+func (c *LFU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.items[key]
+	return ok
+}
+
+// Returns the keys in the cache in no particular order.
+func (c *LFU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]K, 0, len(c.items))
+	for k := range c.items {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Returns the access frequency of key or 0 if not present.
+func (c *LFU[K, V]) Frequency(key K) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return 0
+	}
+	return e.Value.(*lfuEntry[K, V]).freq
+}
+
+// Returns the number of entries in the cache.
+func (c *LFU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.items)
+}
+
+// Removes all entries from the cache.
+func (c *LFU[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[K]*list.Element)
+	c.freqs = make(map[int]*list.List)
+	c.minFreq = 0
+}
@@ -0,0 +1,123 @@
+package cache
+
+import "container/list"
+
+// LRU is a cache that evicts the least recently used entry.
+type LRU[K comparable, V any] struct {
+	common[K, V]
+	ll    *list.List // front is the most recently used
+	items map[K]*list.Element
+}
+
+var _ Cache[int, int] = (*LRU[int, int])(nil)
+
+// Creates a new LRU cache holding at most capacity entries.
+// Panics if capacity <= 0.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{
+		common: newCommon[K, V](capacity),
+		ll:     list.New(),
+		items:  make(map[K]*list.Element),
+	}
+}
+
+// Returns the value under key and marks it as most recently used.
+func (c *LRU[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return value, false
+	}
+
+	c.stats.Hits++
+	c.ll.MoveToFront(e)
+	return e.Value.(*entry[K, V]).value, true
+}
+
+// Returns the value under key without marking it as used.
+func (c *LRU[K, V]) Peek(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return value, false
+	}
+	return e.Value.(*entry[K, V]).value, true
+}
+
+// Inserts value under key, evicting the least recently used entry if full.
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.unlock()
+
+	if e, ok := c.items[key]; ok {
+		e.Value.(*entry[K, V]).value = value
+		c.ll.MoveToFront(e)
+		return
+	}
+
+	if c.ll.Len() >= c.capacity {
+		oldest := c.ll.Back()
+		ent := c.ll.Remove(oldest).(*entry[K, V])
+		delete(c.items, ent.key)
+		c.evict(ent.key, ent.value)
+	}
+
+	c.items[key] = c.ll.PushFront(&entry[K, V]{key, value})
+}
+
+// Removes key from the cache. Returns true if it was present.
+func (c *LRU[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.ll.Remove(e)
+	delete(c.items, key)
+	return true
+}
+
+// Returns true if key is in the cache.
+func (c *LRU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.items[key]
+	return ok
+}
+
+// Returns the keys from the most to the least recently used.
+func (c *LRU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]K, 0, c.ll.Len())
+	for e := c.ll.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.(*entry[K, V]).key)
+	}
+	return keys
+}
+
+// Returns the number of entries in the cache.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+// Removes all entries from the cache.
+func (c *LRU[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[K]*list.Element)
+}
@@ -0,0 +1,316 @@
+// Directed acyclic word graph (DAWG), also known as a minimal acyclic
+// finite-state automaton (MA-FSA).
+//
+// A DAWG is a trie in which identical subtrees are merged, so words share
+// suffixes as well as prefixes. It is built in one pass from sorted words
+// using the incremental algorithm of Daciuk et al. and is immutable once
+// built.
+//
+// Every node records how many words are reachable from it, which gives
+// a minimal perfect hash: Index maps each word to its rank in sorted
+// order and WordAt maps a rank back to the word.
+package dawg
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+)
+
+// ErrUnsorted is returned when words are not added in sorted order.
+var ErrUnsorted = errors.New("dawg: words must be added in sorted order")
+
+// edge is a labelled transition to another node.
+type edge struct {
+	label rune
+	to    *node
+}
+
+// node is a state of the automaton.
+// edges are sorted by label.
+type node struct {
+	edges []edge
+	final bool
+	count int // words reachable from this node, including itself if final
+	id    int // assigned when the node is registered as minimized
+}
+
+// returns the node reached over label or nil.
+func (n *node) next(label rune) *node {
+	i := sort.Search(len(n.edges), func(i int) bool {
+		return n.edges[i].label >= label
+	})
+
+	if i < len(n.edges) && n.edges[i].label == label {
+		return n.edges[i].to
+	}
+	return nil
+}
+
+// signature identifies nodes with equal right languages. Children
+// are already minimized, so comparing their ids is sufficient.
+func (n *node) signature() string {
+	buf := make([]byte, 0, 1+len(n.edges)*4)
+	if n.final {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+
+	for _, e := range n.edges {
+		buf = binary.AppendUvarint(buf, uint64(e.label))
+		buf = binary.AppendUvarint(buf, uint64(e.to.id))
+	}
+	return string(buf)
+}
+
+// unchecked is a node of the last word that may still change.
+type unchecked struct {
+	parent *node
+	label  rune
+	child  *node
+}
+
+// Builder constructs a DAWG from words added in sorted order.
+type Builder struct {
+	root      *node
+	prev      []rune
+	started   bool
+	unchecked []unchecked
+	register  map[string]*node
+	nextID    int
+}
+
+// Creates a new Builder.
+func NewBuilder() *Builder {
+	return &Builder{root: &node{}, register: map[string]*node{}, nextID: 1}
+}
+
+// Adds word to the DAWG. Words must be added in ascending order;
+// duplicates are ignored.
+// Returns ErrUnsorted if word sorts before the previous word.
+func (b *Builder) Add(word string) error {
+	runes := []rune(word)
+
+	// length of the common prefix with the previous word
+	l := 0
+	for l < len(runes) && l < len(b.prev) && runes[l] == b.prev[l] {
+		l++
+	}
+
+	if b.started {
+		if l == len(runes) && l == len(b.prev) {
+			return nil
+		}
+
+		// runes compare like the UTF-8 bytes of the words
+		if l == len(runes) || (l < len(b.prev) && runes[l] < b.prev[l]) {
+			return ErrUnsorted
+		}
+	}
+	b.started = true
+
+	// the suffix of the previous word after the common prefix is final
+	b.minimize(l)
+
+	n := b.root
+	if len(b.unchecked) > 0 {
+		n = b.unchecked[len(b.unchecked)-1].child
+	}
+
+	for _, r := range runes[l:] {
+		child := &node{}
+		n.edges = append(n.edges, edge{r, child})
+		b.unchecked = append(b.unchecked, unchecked{n, r, child})
+		n = child
+	}
+
+	n.final = true
+	b.prev = runes
+	return nil
+}
+
+// minimize replaces unchecked nodes deeper than depth with an equivalent
+// registered node, or registers them.
+func (b *Builder) minimize(depth int) {
+	for i := len(b.unchecked) - 1; i >= depth; i-- {
+		u := b.unchecked[i]
+		sig := u.child.signature()
+
+		if existing, ok := b.register[sig]; ok {
+			// the child is the parent's last edge, as words are sorted
+			u.parent.edges[len(u.parent.edges)-1].to = existing
+		} else {
+			u.child.id = b.nextID
+			b.nextID++
+			b.register[sig] = u.child
+		}
+	}
+	b.unchecked = b.unchecked[:depth]
+}
+
+// Finishes building and returns the DAWG.
+// The Builder must not be used afterwards.
+func (b *Builder) Finish() *DAWG {
+	b.minimize(0)
+	countWords(b.root)
+
+	d := &DAWG{root: b.root, nodes: len(b.register)}
+	b.register = nil
+	return d
+}
+
+// countWords sets the word count of n and its descendants.
+// Shared nodes are only counted once.
+func countWords(n *node) int {
+	if n.count > 0 {
+		return n.count
+	}
+
+	if n.final {
+		n.count = 1
+	}
+
+	for _, e := range n.edges {
+		n.count += countWords(e.to)
+	}
+	return n.count
+}
+
+// DAWG is an immutable minimal automaton of a set of words.
+// It is safe for concurrent use.
+type DAWG struct {
+	root  *node
+	nodes int
+}
+
+// Builds a DAWG from words, which must be sorted.
+// Returns ErrUnsorted otherwise.
+func Build(words ...string) (*DAWG, error) {
+	b := NewBuilder()
+	for _, w := range words {
+		if err := b.Add(w); err != nil {
+			return nil, err
+		}
+	}
+	return b.Finish(), nil
+}
+
+// returns the node at the end of prefix or nil.
+func (d *DAWG) find(prefix string) *node {
+	n := d.root
+	for _, r := range prefix {
+		if n = n.next(r); n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// Returns true if word is in the DAWG.
+//
+// O(m log σ) where m is the length of word and σ the alphabet size.
+func (d *DAWG) Exists(word string) bool {
+	n := d.find(word)
+	return n != nil && n.final
+}
+
+// appends the words below n to words in lexicographic order.
+func suggestionsRec(n *node, prefix []rune, words *[]string) {
+	if n.final {
+		*words = append(*words, string(prefix))
+	}
+
+	for _, e := range n.edges {
+		suggestionsRec(e.to, append(prefix, e.label), words)
+	}
+}
+
+// Returns the words starting with prefix in lexicographic order.
+func (d *DAWG) Suggestions(prefix string) []string {
+	words := []string{}
+
+	n := d.find(prefix)
+	if n == nil {
+		return words
+	}
+
+	suggestionsRec(n, []rune(prefix), &words)
+	return words
+}
+
+// Returns all words in lexicographic order.
+func (d *DAWG) Words() []string {
+	return d.Suggestions("")
+}
+
+// Returns the number of words.
+func (d *DAWG) Size() int {
+	return d.root.count
+}
+
+// Returns the number of nodes, excluding the root.
+func (d *DAWG) Nodes() int {
+	return d.nodes
+}
+
+// Returns the rank of word among all words in sorted order,
+// from 0 to Size()-1, and false if word is not in the DAWG.
+func (d *DAWG) Index(word string) (int, bool) {
+	n := d.root
+	index := 0
+
+	for _, r := range word {
+		if n.final {
+			index++
+		}
+
+		found := false
+		for _, e := range n.edges {
+			if e.label == r {
+				n = e.to
+				found = true
+				break
+			}
+			index += e.to.count
+		}
+
+		if !found {
+			return 0, false
+		}
+	}
+
+	if !n.final {
+		return 0, false
+	}
+	return index, true
+}
+
+// Returns the word with the given rank, the inverse of Index.
+// ok is false if index is out of range.
+func (d *DAWG) WordAt(index int) (word string, ok bool) {
+	if index < 0 || index >= d.root.count {
+		return "", false
+	}
+
+	n := d.root
+	runes := []rune{}
+
+	for {
+		if n.final {
+			if index == 0 {
+				return string(runes), true
+			}
+			index--
+		}
+
+		for _, e := range n.edges {
+			if index < e.to.count {
+				runes = append(runes, e.label)
+				n = e.to
+				break
+			}
+			index -= e.to.count
+		}
+	}
+}
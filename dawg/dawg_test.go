@@ -0,0 +1,120 @@
+package dawg_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/abiiranathan/algo/dawg"
+	"github.com/abiiranathan/algo/trie"
+)
+
+func TestDAWG(t *testing.T) {
+	words := []string{"tap", "taps", "top", "tops", "tap", "東京", "京都"}
+	sort.Strings(words)
+
+	d, err := dawg.Build(words...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Size() != 6 {
+		t.Errorf("expected 6 words, got %d", d.Size())
+	}
+
+	tr := trie.NewTrie()
+	tr.Insert(words...)
+	if d.Nodes() >= tr.Nodes() {
+		t.Errorf("DAWG should have fewer nodes than the trie, got %d vs %d", d.Nodes(), tr.Nodes())
+	}
+
+	// a and o have the same suffixes, so the nodes are t, a/o, p, s
+	small, _ := dawg.Build("tap", "taps", "top", "tops")
+	if small.Nodes() != 4 {
+		t.Errorf("expected 4 nodes for tap/taps/top/tops, got %d", small.Nodes())
+	}
+
+	for _, w := range words {
+		if !d.Exists(w) {
+			t.Errorf("expected %q to exist", w)
+		}
+	}
+
+	for _, w := range []string{"ta", "tapss", "to", "", "東"} {
+		if d.Exists(w) {
+			t.Errorf("expected %q not to exist", w)
+		}
+	}
+
+	got := d.Suggestions("t")
+	if len(got) != 4 || got[0] != "tap" || got[3] != "tops" {
+		t.Errorf("unexpected suggestions: %v", got)
+	}
+
+	if len(d.Suggestions("x")) != 0 {
+		t.Errorf("expected no suggestions for x")
+	}
+
+	// perfect hashing: ranks in sorted order
+	all := d.Words()
+	for i, w := range all {
+		index, ok := d.Index(w)
+		if !ok || index != i {
+			t.Errorf("Index(%q) = %d, want %d", w, index, i)
+		}
+
+		word, ok := d.WordAt(i)
+		if !ok || word != w {
+			t.Errorf("WordAt(%d) = %q, want %q", i, word, w)
+		}
+	}
+
+	if _, ok := d.Index("to"); ok {
+		t.Errorf("Index should fail for words not in the DAWG")
+	}
+
+	if _, ok := d.WordAt(len(all)); ok {
+		t.Errorf("WordAt should fail out of range")
+	}
+}
+
+func TestDAWGUnsorted(t *testing.T) {
+	b := dawg.NewBuilder()
+	b.Add("b")
+
+	if err := b.Add("a"); err != dawg.ErrUnsorted {
+		t.Errorf("expected ErrUnsorted, got %v", err)
+	}
+
+	if err := b.Add(""); err != dawg.ErrUnsorted {
+		t.Errorf("expected ErrUnsorted for a prefix, got %v", err)
+	}
+
+	if _, err := dawg.Build("ab", "a"); err != dawg.ErrUnsorted {
+		t.Errorf("expected ErrUnsorted, got %v", err)
+	}
+
+	d := b.Finish()
+	if d.Size() != 1 || !d.Exists("b") {
+		t.Errorf("rejected words should not be added")
+	}
+
+	empty, _ := dawg.Build()
+	if empty.Size() != 0 || len(empty.Words()) != 0 {
+		t.Errorf("expected an empty DAWG")
+	}
+}
+
+func TestDAWGEmptyWord(t *testing.T) {
+	d, err := dawg.Build("", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !d.Exists("") || d.Size() != 2 {
+		t.Errorf("the empty word should be stored")
+	}
+
+	if i, _ := d.Index("a"); i != 1 {
+		t.Errorf("expected a at index 1, got %d", i)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/abiiranathan/algo
 
-go 1.19
+go 1.24
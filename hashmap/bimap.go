@@ -0,0 +1,177 @@
+package hashmap
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrValueExists is returned by BiMap.Set when the value is already
+// mapped to a different key.
+var ErrValueExists = errors.New("hashmap: value already mapped to another key")
+
+// BiMap is a bidirectional map in which values are unique,
+// so keys can be looked up by value as well.
+//
+// Like HashMap, it is guarded by a sync.RWMutex.
+type BiMap[K comparable, V comparable] struct {
+	forward map[K]V
+	reverse map[V]K
+	mutex   *sync.RWMutex
+}
+
+// Instantiates a new BiMap.
+func NewBiMap[K comparable, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward: make(map[K]V),
+		reverse: make(map[V]K),
+		mutex:   &sync.RWMutex{},
+	}
+}
+
+// Returns V and true if key in map.
+func (b *BiMap[K, V]) Get(key K) (V, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	v, ok := b.forward[key]
+	return v, ok
+}
+
+// Returns the key mapped to value and true if present.
+func (b *BiMap[K, V]) GetByValue(value V) (K, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	k, ok := b.reverse[value]
+	return k, ok
+}
+
+// Inserts value under key, replacing the previous value of key.
+// Returns ErrValueExists if value is already mapped to another key.
+func (b *BiMap[K, V]) Set(key K, value V) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if k, ok := b.reverse[value]; ok && k != key {
+		return ErrValueExists
+	}
+
+	if old, ok := b.forward[key]; ok {
+		delete(b.reverse, old)
+	}
+
+	b.forward[key] = value
+	b.reverse[value] = key
+	return nil
+}
+
+// Like Set but if value is mapped to another key, that entry is removed first.
+func (b *BiMap[K, V]) ForceSet(key K, value V) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if k, ok := b.reverse[value]; ok {
+		delete(b.forward, k)
+	}
+
+	if old, ok := b.forward[key]; ok {
+		delete(b.reverse, old)
+	}
+
+	b.forward[key] = value
+	b.reverse[value] = key
+}
+
+// deletes the entry with the specified key
+func (b *BiMap[K, V]) Delete(key K) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if v, ok := b.forward[key]; ok {
+		delete(b.forward, key)
+		delete(b.reverse, v)
+	}
+}
+
+// deletes the entry with the specified value
+func (b *BiMap[K, V]) DeleteByValue(value V) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if k, ok := b.reverse[value]; ok {
+		delete(b.forward, k)
+		delete(b.reverse, value)
+	}
+}
+
+// Returns true if key in map
+func (b *BiMap[K, V]) Contains(key K) bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	_, ok := b.forward[key]
+	return ok
+}
+
+// Returns true if value in map
+func (b *BiMap[K, V]) ContainsValue(value V) bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	_, ok := b.reverse[value]
+	return ok
+}
+
+// deletes all elements in the map
+func (b *BiMap[K, V]) Clear() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.forward = make(map[K]V)
+	b.reverse = make(map[V]K)
+}
+
+// Returns the number of elements in the map
+func (b *BiMap[K, V]) Len() int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return len(b.forward)
+}
+
+// Returns a slice of the keys in the map
+func (b *BiMap[K, V]) Keys() []K {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	keys := make([]K, 0, len(b.forward))
+	for k := range b.forward {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Returns a slice of all the values in the map
+func (b *BiMap[K, V]) Values() []V {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	values := make([]V, 0, len(b.reverse))
+	for v := range b.reverse {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Returns a new BiMap with keys and values swapped.
+func (b *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	inv := NewBiMap[V, K]()
+	for k, v := range b.forward {
+		inv.forward[v] = k
+		inv.reverse[k] = v
+	}
+	return inv
+}
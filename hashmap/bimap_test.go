@@ -0,0 +1,53 @@
+package hashmap_test
+
+import (
+	"testing"
+
+	"github.com/abiiranathan/algo/hashmap"
+)
+
+func TestBiMap(t *testing.T) {
+	t.Parallel()
+	b := hashmap.NewBiMap[int, string]()
+
+	if err := b.Set(1, "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Set(2, "bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Set(3, "alice"); err != hashmap.ErrValueExists {
+		t.Errorf("expected ErrValueExists, got %v", err)
+	}
+
+	if k, ok := b.GetByValue("bob"); !ok || k != 2 {
+		t.Error("GetByValue failed")
+	}
+
+	// renaming a key frees the old value
+	if err := b.Set(1, "alicia"); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.ContainsValue("alice") || !b.ContainsValue("alicia") {
+		t.Error("Set should replace the reverse index entry")
+	}
+
+	b.ForceSet(3, "bob")
+	if b.Contains(2) || b.Len() != 2 {
+		t.Error("ForceSet should remove the key previously mapped to the value")
+	}
+
+	inv := b.Inverse()
+	if k, _ := inv.Get("bob"); k != 3 {
+		t.Error("Inverse failed")
+	}
+
+	b.DeleteByValue("bob")
+	b.Delete(1)
+	if b.Len() != 0 || len(b.Keys()) != 0 || len(b.Values()) != 0 {
+		t.Error("deletes should remove both directions")
+	}
+}
@@ -0,0 +1,80 @@
+package hashmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"time"
+)
+
+// MarshalJSON encodes the map as a JSON object.
+// The contents are copied under the read lock before encoding.
+// Expiry times are not encoded.
+func (s *HashMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Snapshot())
+}
+
+// UnmarshalJSON replaces the contents of the map with the decoded JSON object.
+// Decoding into a zero HashMap initializes it.
+func (s *HashMap[K, V]) UnmarshalJSON(data []byte) error {
+	var m map[K]V
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	s.replace(m)
+	return nil
+}
+
+// MarshalBinary encodes the map using encoding/gob.
+// The contents are copied under the read lock before encoding.
+// Expiry times are not encoded.
+func (s *HashMap[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.Snapshot()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the contents of the map with data
+// produced by MarshalBinary.
+// Decoding into a zero HashMap initializes it.
+func (s *HashMap[K, V]) UnmarshalBinary(data []byte) error {
+	var m map[K]V
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil {
+		return err
+	}
+
+	s.replace(m)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder. See MarshalBinary.
+func (s *HashMap[K, V]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder. See UnmarshalBinary.
+func (s *HashMap[K, V]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// replace swaps the contents of the map for m under a single lock.
+// New entries get the default TTL of the map.
+func (s *HashMap[K, V]) replace(m map[K]V) {
+	// zero HashMap, e.g. a struct field being decoded
+	if s.mutex == nil {
+		*s = *NewHashMap[K, V]()
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	s.m = make(map[K]V, len(m))
+	s.expires = make(map[K]time.Time)
+	s.record(Event[K, V]{Type: EventClear})
+	for k, v := range m {
+		s.store(k, v, s.defaultTTL)
+	}
+}
@@ -0,0 +1,92 @@
+package hashmap_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/abiiranathan/algo/hashmap"
+)
+
+func TestMapJSON(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, bool]()
+	m.Set("dark-mode", true)
+	m.Set("beta", false)
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"beta":false,"dark-mode":true}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	decoded := hashmap.NewHashMap[string, bool]()
+	decoded.Set("stale", true)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Len() != 2 || decoded.Contains("stale") {
+		t.Error("UnmarshalJSON should replace the contents of the map")
+	}
+
+	// zero value inside a struct
+	var registry struct {
+		Flags hashmap.HashMap[string, bool]
+	}
+
+	if err := json.Unmarshal([]byte(`{"Flags":{"beta":true}}`), &registry); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := registry.Flags.Get("beta"); !ok || !v {
+		t.Error("UnmarshalJSON should initialize a zero HashMap")
+	}
+
+	if err := json.Unmarshal([]byte(`[1, 2]`), decoded); err == nil {
+		t.Error("UnmarshalJSON should fail on invalid input")
+	}
+}
+
+func TestMapGob(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, string]()
+	m.Set(1, "one")
+	m.Set(2, "two")
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(m); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := hashmap.NewHashMap[int, string]()
+	if err := gob.NewDecoder(&buf).Decode(decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, _ := decoded.Get(2); v != "two" || decoded.Len() != 2 {
+		t.Error("gob round trip failed")
+	}
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var zero hashmap.HashMap[int, string]
+	if err := zero.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, _ := zero.Get(1); v != "one" || zero.Len() != 2 {
+		t.Error("binary round trip failed")
+	}
+
+	if err := zero.UnmarshalBinary([]byte("garbage")); err == nil {
+		t.Error("UnmarshalBinary should fail on invalid input")
+	}
+}
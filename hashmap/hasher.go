@@ -0,0 +1,31 @@
+package hashmap
+
+import "hash/maphash"
+
+// Hasher computes the hash of a key for an OpenMap.
+// Keys that are equal must have equal hashes.
+type Hasher[K any] func(key K) uint64
+
+// Returns a Hasher for byte slices seeded with a random seed.
+func BytesHasher() Hasher[[]byte] {
+	seed := maphash.MakeSeed()
+	return func(key []byte) uint64 {
+		return maphash.Bytes(seed, key)
+	}
+}
+
+// Returns a Hasher for strings seeded with a random seed.
+func StringHasher() Hasher[string] {
+	seed := maphash.MakeSeed()
+	return func(key string) uint64 {
+		return maphash.String(seed, key)
+	}
+}
+
+// Returns a Hasher for any comparable type seeded with a random seed.
+func ComparableHasher[K comparable]() Hasher[K] {
+	seed := maphash.MakeSeed()
+	return func(key K) uint64 {
+		return maphash.Comparable(seed, key)
+	}
+}
@@ -1,57 +1,88 @@
 // implentation of a safe concurrent generic map
 package hashmap
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 // HashMap is a generic implementation of a safe map.
 // K is the key and V is the value.
 //
 // Important for multiple concurrent reads and few writes.
 // since it's guarded by sync.RWMutex
+//
+// Entries may optionally expire, see SetWithTTL.
 type HashMap[K comparable, V any] struct {
 	m     map[K]V
 	mutex *sync.RWMutex
+
+	// expiry times of entries that have a TTL.
+	expires    map[K]time.Time
+	defaultTTL time.Duration
+	onEvict    func(key K, value V)
+	evicted    []entry[K, V] // expired entries awaiting the onEvict callback
+	janitor    *janitor
+
+	// change subscriptions, see Subscribe.
+	subs    []*subscriber[K, V]
+	tickets map[*subscriber[K, V]]uint64 // events the writer waits for, see record
 }
 
 // Instantiates a new AsyncMap.
 func NewHashMap[K comparable, V any]() *HashMap[K, V] {
 	return &HashMap[K, V]{
-		m:     make(map[K]V),
-		mutex: &sync.RWMutex{},
+		m:       make(map[K]V),
+		mutex:   &sync.RWMutex{},
+		expires: make(map[K]time.Time),
 	}
 }
 
 // Returns V and true if key in map.
+// An expired entry is removed and reported as missing.
 func (s *HashMap[K, V]) Get(key K) (V, bool) {
 	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
 	v, ok := s.m[key]
-	return v, ok
+	if !ok || !s.expired(key, time.Now()) {
+		s.mutex.RUnlock()
+		return v, ok
+	}
+	s.mutex.RUnlock()
+
+	// upgrade to a write lock to remove the expired entry
+	s.lock()
+	defer s.unlock()
+
+	return s.load(key, time.Now())
 }
 
-// Inserts V in map under the key
+// Inserts V in map under the key with the default TTL of the map.
 func (s *HashMap[K, V]) Set(key K, value V) {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	s.lock()
+	defer s.unlock()
 
-	s.m[key] = value
+	s.store(key, value, s.defaultTTL)
 }
 
 // deletes the element with the specified key
 func (s *HashMap[K, V]) Delete(key K) {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	delete(s.m, key)
+	s.lock()
+	defer s.unlock()
+
+	if v, ok := s.load(key, time.Now()); ok {
+		s.remove(key, v)
+	}
 }
 
 // deletes all elements in the map
 func (s *HashMap[K, V]) Clear() {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	s.lock()
+	defer s.unlock()
 
 	// delete all elements in the map
 	s.m = make(map[K]V)
+	s.expires = make(map[K]time.Time)
+	s.record(Event[K, V]{Type: EventClear})
 }
 
 // Returns a slice of the keys in the map
@@ -59,11 +90,12 @@ func (s *HashMap[K, V]) Keys() []K {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	keys := make([]K, len(s.m))
-	i := 0
+	now := time.Now()
+	keys := make([]K, 0, len(s.m))
 	for k := range s.m {
-		keys[i] = k
-		i++
+		if !s.expired(k, now) {
+			keys = append(keys, k)
+		}
 	}
 	return keys
 }
@@ -75,21 +107,22 @@ func (s *HashMap[K, V]) Values() []V {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	values := make([]V, len(s.m))
-	i := 0
-	for _, v := range s.m {
-		values[i] = v
-		i++
+	now := time.Now()
+	values := make([]V, 0, len(s.m))
+	for k, v := range s.m {
+		if !s.expired(k, now) {
+			values = append(values, v)
+		}
 	}
 	return values
 }
 
-// deletes all elements in the map
+// Returns the number of elements in the map
 func (s *HashMap[K, V]) Len() int {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	return len(s.m)
+	return s.len(time.Now())
 }
 
 // Returns true is map has zero elements
@@ -97,7 +130,7 @@ func (s *HashMap[K, V]) IsEmpty() bool {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	return len(s.m) == 0
+	return s.len(time.Now()) == 0
 }
 
 // Returns true if key in map
@@ -106,5 +139,117 @@ func (s *HashMap[K, V]) Contains(key K) bool {
 	defer s.mutex.RUnlock()
 
 	_, ok := s.m[key]
-	return ok
+	return ok && !s.expired(key, time.Now())
+}
+
+// Returns the existing value for key if present.
+// Otherwise stores and returns value.
+// loaded is true if the value was loaded, false if stored.
+func (s *HashMap[K, V]) GetOrSet(key K, value V) (actual V, loaded bool) {
+	s.lock()
+	defer s.unlock()
+
+	if v, ok := s.load(key, time.Now()); ok {
+		return v, true
+	}
+	s.store(key, value, s.defaultTTL)
+	return value, false
+}
+
+// Like GetOrSet but the value is only created by calling fn if key is absent.
+// fn is called while the lock is held and must not access the map.
+func (s *HashMap[K, V]) GetOrCompute(key K, fn func() V) (actual V, loaded bool) {
+	s.lock()
+	defer s.unlock()
+
+	if v, ok := s.load(key, time.Now()); ok {
+		return v, true
+	}
+	v := fn()
+	s.store(key, v, s.defaultTTL)
+	return v, false
+}
+
+// Deletes the value for key, returning the previous value if any.
+// loaded is true if the key was present.
+func (s *HashMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	s.lock()
+	defer s.unlock()
+
+	value, loaded = s.load(key, time.Now())
+	if loaded {
+		s.remove(key, value)
+	}
+	return value, loaded
+}
+
+// Update atomically replaces the value under key with the result of fn.
+// fn receives the current value and whether it exists.
+// If fn returns keep as false, the key is deleted instead.
+// Returns the value now stored and whether the key is present.
+// An existing entry keeps its expiry; a new key gets the default TTL.
+//
+// fn is called while the lock is held and must not access the map.
+func (s *HashMap[K, V]) Update(key K, fn func(old V, ok bool) (value V, keep bool)) (V, bool) {
+	s.lock()
+	defer s.unlock()
+
+	old, ok := s.load(key, time.Now())
+	value, keep := fn(old, ok)
+	if !keep {
+		if ok {
+			s.remove(key, old)
+		}
+		var zero V
+		return zero, false
+	}
+	s.modify(key, value)
+	return value, true
+}
+
+// Compute atomically stores the result of fn under key and returns it.
+// fn receives the current value and whether it exists.
+// An existing entry keeps its expiry; a new key gets the default TTL.
+//
+// fn is called while the lock is held and must not access the map.
+func (s *HashMap[K, V]) Compute(key K, fn func(old V, ok bool) V) V {
+	s.lock()
+	defer s.unlock()
+
+	old, ok := s.load(key, time.Now())
+	value := fn(old, ok)
+	s.modify(key, value)
+	return value
+}
+
+// Swaps the value under key for new if the current value equals old.
+// Returns true if the swap was performed. The entry keeps its expiry.
+//
+// Implemented as a function since V must be comparable.
+func CompareAndSwap[K comparable, V comparable](s *HashMap[K, V], key K, old, new V) bool {
+	s.lock()
+	defer s.unlock()
+
+	v, ok := s.load(key, time.Now())
+	if !ok || v != old {
+		return false
+	}
+	s.modify(key, new)
+	return true
+}
+
+// Deletes the entry for key if its value equals old.
+// Returns true if the entry was deleted.
+//
+// Implemented as a function since V must be comparable.
+func CompareAndDelete[K comparable, V comparable](s *HashMap[K, V], key K, old V) bool {
+	s.lock()
+	defer s.unlock()
+
+	v, ok := s.load(key, time.Now())
+	if !ok || v != old {
+		return false
+	}
+	s.remove(key, v)
+	return true
 }
@@ -1,6 +1,7 @@
 package hashmap_test
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/abiiranathan/algo/hashmap"
@@ -124,3 +125,112 @@ func TestMapIsEmpty(t *testing.T) {
 		t.Error("AsyncMapIsEmpty failed")
 	}
 }
+
+func TestMapGetOrSet(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, int]()
+
+	v, loaded := m.GetOrSet("a", 1)
+	if loaded || v != 1 {
+		t.Error("GetOrSet should store on a missing key")
+	}
+
+	v, loaded = m.GetOrSet("a", 2)
+	if !loaded || v != 1 {
+		t.Error("GetOrSet should load an existing key")
+	}
+
+	calls := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.GetOrCompute("session", func() int {
+				calls++
+				return 42
+			})
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("GetOrCompute should initialise once, got %d calls", calls)
+	}
+}
+
+func TestMapLoadAndDelete(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, int]()
+	m.Set("a", 1)
+
+	v, loaded := m.LoadAndDelete("a")
+	if !loaded || v != 1 || m.Contains("a") {
+		t.Error("LoadAndDelete failed")
+	}
+
+	if _, loaded = m.LoadAndDelete("a"); loaded {
+		t.Error("LoadAndDelete on missing key should return false")
+	}
+}
+
+func TestMapUpdateAndCompute(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Compute("counter", func(old int, ok bool) int {
+				return old + 1
+			})
+		}()
+	}
+	wg.Wait()
+
+	if v, _ := m.Get("counter"); v != 100 {
+		t.Errorf("Compute should be atomic, got %d", v)
+	}
+
+	v, ok := m.Update("counter", func(old int, ok bool) (int, bool) {
+		return old * 2, true
+	})
+	if !ok || v != 200 {
+		t.Errorf("Update should store the new value, got %d", v)
+	}
+
+	_, ok = m.Update("counter", func(old int, ok bool) (int, bool) {
+		return 0, false
+	})
+	if ok || m.Contains("counter") {
+		t.Error("Update returning keep=false should delete the key")
+	}
+}
+
+func TestMapCompareAndSwap(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, int]()
+	m.Set("a", 1)
+
+	if hashmap.CompareAndSwap(m, "a", 2, 3) {
+		t.Error("CompareAndSwap should fail on a mismatched value")
+	}
+
+	if !hashmap.CompareAndSwap(m, "a", 1, 3) {
+		t.Error("CompareAndSwap should succeed on a matching value")
+	}
+
+	if hashmap.CompareAndSwap(m, "missing", 0, 1) {
+		t.Error("CompareAndSwap should fail on a missing key")
+	}
+
+	if hashmap.CompareAndDelete(m, "a", 1) {
+		t.Error("CompareAndDelete should fail on a mismatched value")
+	}
+
+	if !hashmap.CompareAndDelete(m, "a", 3) || m.Contains("a") {
+		t.Error("CompareAndDelete should delete on a matching value")
+	}
+}
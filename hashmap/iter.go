@@ -0,0 +1,187 @@
+package hashmap
+
+import (
+	"iter"
+	"time"
+)
+
+// Range calls fn for each key and value in the map until fn returns false.
+//
+// It iterates over a snapshot taken under a single lock, so fn sees a
+// consistent view of the map and may safely access or modify it.
+func (s *HashMap[K, V]) Range(fn func(key K, value V) bool) {
+	for k, v := range s.Snapshot() {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the key-value pairs in the map.
+//
+// It iterates over a snapshot taken when iteration starts, so the loop
+// body may safely modify the map.
+func (s *HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s.Snapshot() {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a copy of the map's contents taken under a single lock.
+func (s *HashMap[K, V]) Snapshot() map[K]V {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	now := time.Now()
+	m := make(map[K]V, len(s.m))
+	for k, v := range s.m {
+		if !s.expired(k, now) {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// Inserts all entries of m under a single lock.
+func (s *HashMap[K, V]) SetAll(m map[K]V) {
+	s.lock()
+	defer s.unlock()
+
+	for k, v := range m {
+		s.store(k, v, s.defaultTTL)
+	}
+}
+
+// Deletes every entry for which pred returns true under a single lock.
+// Returns the number of entries deleted.
+//
+// pred is called while the lock is held and must not access the map.
+func (s *HashMap[K, V]) DeleteFunc(pred func(key K, value V) bool) int {
+	s.lock()
+	defer s.unlock()
+
+	now := time.Now()
+	n := 0
+	for k := range s.m {
+		v, ok := s.load(k, now)
+		if ok && pred(k, v) {
+			s.remove(k, v)
+			n++
+		}
+	}
+	return n
+}
+
+// Returns a new map with the entries for which pred returns true.
+// Expiry times are carried over to the new map.
+//
+// pred is called on a snapshot taken under a single lock, with no lock
+// held, so it may safely access the map.
+func (s *HashMap[K, V]) Filter(pred func(key K, value V) bool) *HashMap[K, V] {
+	s.mutex.RLock()
+	m := make(map[K]V, len(s.m))
+	expires := make(map[K]time.Time, len(s.expires))
+	now := time.Now()
+	for k, v := range s.m {
+		if s.expired(k, now) {
+			continue
+		}
+
+		m[k] = v
+		if exp, ok := s.expires[k]; ok {
+			expires[k] = exp
+		}
+	}
+	defaultTTL := s.defaultTTL
+	s.mutex.RUnlock()
+
+	f := NewHashMap[K, V]()
+	f.defaultTTL = defaultTTL
+	for k, v := range m {
+		if !pred(k, v) {
+			continue
+		}
+
+		f.m[k] = v
+		if exp, ok := expires[k]; ok {
+			f.expires[k] = exp
+		}
+	}
+	return f
+}
+
+// Range calls fn for each key and value in the map until fn returns false.
+//
+// Each shard is snapshotted when the iteration reaches it,
+// so fn may safely access or modify the map.
+func (s *ShardedHashMap[K, V]) Range(fn func(key K, value V) bool) {
+	more := true
+	for _, shard := range s.shards {
+		shard.Range(func(key K, value V) bool {
+			more = fn(key, value)
+			return more
+		})
+
+		if !more {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the key-value pairs in the map.
+// Each shard is snapshotted when the iterator reaches it.
+func (s *ShardedHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, shard := range s.shards {
+			for k, v := range shard.All() {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns a copy of the map's contents.
+// Shards are copied one at a time.
+func (s *ShardedHashMap[K, V]) Snapshot() map[K]V {
+	m := make(map[K]V, s.Len())
+	for _, shard := range s.shards {
+		for k, v := range shard.Snapshot() {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// Inserts all entries of m, locking each shard once.
+func (s *ShardedHashMap[K, V]) SetAll(m map[K]V) {
+	parts := make([]map[K]V, len(s.shards))
+	for k, v := range m {
+		i := s.index(k)
+		if parts[i] == nil {
+			parts[i] = make(map[K]V)
+		}
+		parts[i][k] = v
+	}
+
+	for i, part := range parts {
+		if part != nil {
+			s.shards[i].SetAll(part)
+		}
+	}
+}
+
+// Deletes every entry for which pred returns true.
+// Returns the number of entries deleted.
+func (s *ShardedHashMap[K, V]) DeleteFunc(pred func(key K, value V) bool) int {
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.DeleteFunc(pred)
+	}
+	return n
+}
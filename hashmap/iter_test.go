@@ -0,0 +1,179 @@
+package hashmap_test
+
+import (
+	"testing"
+
+	"github.com/abiiranathan/algo/hashmap"
+)
+
+func TestMapRange(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, int]()
+	m.SetAll(map[int]int{1: 10, 2: 20, 3: 30})
+
+	if m.Len() != 3 {
+		t.Errorf("SetAll should insert 3 entries, got %d", m.Len())
+	}
+
+	sum := 0
+	m.Range(func(k, v int) bool {
+		if v != k*10 {
+			t.Errorf("Range paired key %d with value %d", k, v)
+		}
+		sum += v
+		return true
+	})
+
+	if sum != 60 {
+		t.Errorf("Range should visit every entry, got sum %d", sum)
+	}
+
+	calls := 0
+	m.Range(func(k, v int) bool {
+		calls++
+		return false
+	})
+
+	if calls != 1 {
+		t.Errorf("Range should stop when fn returns false, got %d calls", calls)
+	}
+}
+
+func TestMapRangeReadsDuringWrites(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, int]()
+	sm := hashmap.NewShardedHashMap[int, int](4)
+	for i := 0; i < 100; i++ {
+		m.Set(i, i)
+		sm.Set(i, i)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				m.Set(i%100, i)
+				sm.Set(i%100, i)
+			}
+		}
+	}()
+
+	// callbacks read the map while a writer is waiting for the lock
+	finishes(t, "reading from Range", func() {
+		for i := 0; i < 50; i++ {
+			m.Range(func(k, v int) bool {
+				m.Get(k)
+				return true
+			})
+
+			sm.Range(func(k, v int) bool {
+				sm.Get(k)
+				return true
+			})
+
+			m.Filter(func(k, v int) bool {
+				_, ok := m.Get(k)
+				return ok
+			})
+		}
+	})
+}
+
+func TestMapAll(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, int]()
+	m.SetAll(map[int]int{1: 10, 2: 20, 3: 30})
+
+	// the loop body may modify the map
+	for k, v := range m.All() {
+		m.Set(k, v+1)
+	}
+
+	for k, v := range m.Snapshot() {
+		if v != k*10+1 {
+			t.Errorf("expected %d, got %d", k*10+1, v)
+		}
+	}
+
+	n := 0
+	for range m.All() {
+		n++
+		break
+	}
+
+	if n != 1 {
+		t.Error("All should stop on break")
+	}
+}
+
+func TestMapDeleteFuncAndFilter(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, int]()
+	m.SetAll(map[int]int{1: 1, 2: 2, 3: 3, 4: 4})
+
+	even := m.Filter(func(k, v int) bool {
+		return v%2 == 0
+	})
+
+	if even.Len() != 2 || !even.Contains(2) || !even.Contains(4) {
+		t.Error("Filter should return the even entries")
+	}
+
+	if m.Len() != 4 {
+		t.Error("Filter should not modify the original map")
+	}
+
+	n := m.DeleteFunc(func(k, v int) bool {
+		return v > 2
+	})
+
+	if n != 2 || m.Len() != 2 || m.Contains(3) {
+		t.Errorf("DeleteFunc should delete 2 entries, deleted %d", n)
+	}
+}
+
+func TestShardedMapIteration(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewShardedHashMap[int, int](4)
+
+	src := map[int]int{}
+	for i := 0; i < 100; i++ {
+		src[i] = i * 2
+	}
+	m.SetAll(src)
+
+	snap := m.Snapshot()
+	if len(snap) != 100 {
+		t.Errorf("Snapshot should hold 100 entries, got %d", len(snap))
+	}
+
+	n := 0
+	for k, v := range m.All() {
+		if v != k*2 {
+			t.Errorf("All paired key %d with value %d", k, v)
+		}
+		n++
+	}
+
+	if n != 100 {
+		t.Errorf("All should visit 100 entries, got %d", n)
+	}
+
+	calls := 0
+	m.Range(func(k, v int) bool {
+		calls++
+		return calls < 10
+	})
+
+	if calls != 10 {
+		t.Errorf("Range should stop after 10 calls, got %d", calls)
+	}
+
+	if m.DeleteFunc(func(k, v int) bool { return k < 50 }) != 50 || m.Len() != 50 {
+		t.Error("DeleteFunc should delete 50 entries")
+	}
+}
@@ -0,0 +1,143 @@
+package hashmap
+
+import "sync"
+
+// MultiMap maps each key to a set of distinct values.
+//
+// Like HashMap, it is guarded by a sync.RWMutex.
+type MultiMap[K comparable, V comparable] struct {
+	m     map[K]map[V]struct{}
+	size  int // total number of values
+	mutex *sync.RWMutex
+}
+
+// Instantiates a new MultiMap.
+func NewMultiMap[K comparable, V comparable]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{
+		m:     make(map[K]map[V]struct{}),
+		mutex: &sync.RWMutex{},
+	}
+}
+
+// Adds values to the set under key. Values already present are ignored.
+func (mm *MultiMap[K, V]) Put(key K, values ...V) {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	set, ok := mm.m[key]
+	if !ok {
+		if len(values) == 0 {
+			return
+		}
+		set = make(map[V]struct{}, len(values))
+		mm.m[key] = set
+	}
+
+	for _, v := range values {
+		if _, exists := set[v]; !exists {
+			set[v] = struct{}{}
+			mm.size++
+		}
+	}
+}
+
+// Returns all values under key in no particular order.
+// The slice is empty if key is not present.
+func (mm *MultiMap[K, V]) Get(key K) []V {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	set := mm.m[key]
+	values := make([]V, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Returns true if value is in the set under key.
+func (mm *MultiMap[K, V]) Has(key K, value V) bool {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	_, ok := mm.m[key][value]
+	return ok
+}
+
+// Returns true if key has at least one value.
+func (mm *MultiMap[K, V]) Contains(key K) bool {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	_, ok := mm.m[key]
+	return ok
+}
+
+// Removes value from the set under key, dropping the key when
+// its set becomes empty. Returns true if the value was present.
+func (mm *MultiMap[K, V]) RemoveValue(key K, value V) bool {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	set, ok := mm.m[key]
+	if !ok {
+		return false
+	}
+
+	if _, exists := set[value]; !exists {
+		return false
+	}
+
+	delete(set, value)
+	mm.size--
+	if len(set) == 0 {
+		delete(mm.m, key)
+	}
+	return true
+}
+
+// deletes the key and all of its values
+func (mm *MultiMap[K, V]) Delete(key K) {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.size -= len(mm.m[key])
+	delete(mm.m, key)
+}
+
+// deletes all elements in the map
+func (mm *MultiMap[K, V]) Clear() {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.m = make(map[K]map[V]struct{})
+	mm.size = 0
+}
+
+// Returns the number of keys in the map
+func (mm *MultiMap[K, V]) Len() int {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	return len(mm.m)
+}
+
+// Returns the total number of values across all keys
+func (mm *MultiMap[K, V]) Size() int {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	return mm.size
+}
+
+// Returns a slice of the keys in the map
+func (mm *MultiMap[K, V]) Keys() []K {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	keys := make([]K, 0, len(mm.m))
+	for k := range mm.m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,38 @@
+package hashmap_test
+
+import (
+	"testing"
+
+	"github.com/abiiranathan/algo/hashmap"
+)
+
+func TestMultiMap(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewMultiMap[string, int]()
+
+	m.Put("admins", 1, 2)
+	m.Put("admins", 2, 3)
+	m.Put("users", 4)
+	m.Put("empty")
+
+	if len(m.Get("admins")) != 3 || m.Size() != 4 || m.Len() != 2 {
+		t.Errorf("unexpected sizes: admins=%d size=%d len=%d", len(m.Get("admins")), m.Size(), m.Len())
+	}
+
+	if m.Contains("empty") || len(m.Get("empty")) != 0 {
+		t.Error("Put with no values should not add the key")
+	}
+
+	if !m.Has("admins", 2) || m.Has("users", 2) {
+		t.Error("Has failed")
+	}
+
+	if !m.RemoveValue("users", 4) || m.RemoveValue("users", 4) || m.Contains("users") {
+		t.Error("RemoveValue should drop the key once its last value is gone")
+	}
+
+	m.Delete("admins")
+	if m.Size() != 0 || len(m.Keys()) != 0 {
+		t.Error("Delete should remove all values of the key")
+	}
+}
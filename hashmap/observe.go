@@ -0,0 +1,295 @@
+package hashmap
+
+import "sync"
+
+// EventType is the kind of change described by an Event.
+type EventType int
+
+const (
+	EventSet    EventType = iota // a key was inserted or its value replaced
+	EventDelete                  // a key was deleted
+	EventExpire                  // a key was removed because its TTL elapsed
+	EventClear                   // all keys were removed
+)
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case EventSet:
+		return "set"
+	case EventDelete:
+		return "delete"
+	case EventExpire:
+		return "expire"
+	case EventClear:
+		return "clear"
+	}
+	return "unknown"
+}
+
+// Event describes a single change to a HashMap.
+//
+// Old holds the previous value if HadOld is true. New is only set
+// for EventSet. Key, Old and New are zero for EventClear.
+type Event[K comparable, V any] struct {
+	Type   EventType
+	Key    K
+	Old    V
+	New    V
+	HadOld bool
+}
+
+// Policy decides what happens when a subscriber's channel is full.
+type Policy int
+
+const (
+	// Block waits until the subscriber has room, slowing down writers.
+	// Subscribers must not write to the map while the policy is Block.
+	Block Policy = iota
+
+	// DropNewest discards the event that does not fit.
+	DropNewest
+
+	// DropOldest discards the oldest buffered event to make room.
+	DropOldest
+)
+
+// subscriber receives events either on a channel or through a callback.
+//
+// Writers queue events under the map lock and a goroutine per subscriber
+// delivers them, so delivery never runs with the map locked. Writers then
+// wait, without holding any lock, until their events have been handled.
+type subscriber[K comparable, V any] struct {
+	ch     chan Event[K, V]
+	fn     func(Event[K, V])
+	policy Policy
+	done   chan struct{}
+	once   sync.Once
+
+	mutex     *sync.Mutex
+	cond      *sync.Cond // signals new events, progress and cancellation
+	queue     []Event[K, V]
+	queued    uint64 // number of events ever queued
+	handled   uint64 // number of queued events delivered or dropped
+	cancelled bool
+}
+
+// creates a subscriber and starts its delivery goroutine.
+func newSubscriber[K comparable, V any](ch chan Event[K, V], fn func(Event[K, V]), policy Policy) *subscriber[K, V] {
+	mutex := &sync.Mutex{}
+	sub := &subscriber[K, V]{
+		ch:     ch,
+		fn:     fn,
+		policy: policy,
+		done:   make(chan struct{}),
+		mutex:  mutex,
+		cond:   sync.NewCond(mutex),
+	}
+	go sub.run()
+	return sub
+}
+
+// enqueue adds ev to the queue and returns its ticket,
+// the value handled reaches once ev has been handled.
+func (sub *subscriber[K, V]) enqueue(ev Event[K, V]) uint64 {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+
+	if !sub.cancelled {
+		sub.queue = append(sub.queue, ev)
+		sub.queued++
+		sub.cond.Broadcast()
+	}
+	return sub.queued
+}
+
+// wait blocks until the event with ticket has been handled
+// or the subscription is cancelled.
+func (sub *subscriber[K, V]) wait(ticket uint64) {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+
+	for sub.handled < ticket && !sub.cancelled {
+		sub.cond.Wait()
+	}
+}
+
+// cancel stops delivery and wakes up the delivery goroutine
+// and any writers waiting on it.
+func (sub *subscriber[K, V]) cancel() {
+	close(sub.done)
+
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+
+	sub.cancelled = true
+	sub.queue = nil
+	sub.cond.Broadcast()
+}
+
+// run delivers queued events in order until the subscription is
+// cancelled, then closes the channel.
+func (sub *subscriber[K, V]) run() {
+	if sub.ch != nil {
+		defer close(sub.ch)
+	}
+
+	for {
+		sub.mutex.Lock()
+		for len(sub.queue) == 0 && !sub.cancelled {
+			sub.cond.Wait()
+		}
+
+		if sub.cancelled {
+			sub.mutex.Unlock()
+			return
+		}
+
+		events := sub.queue
+		sub.queue = nil
+		sub.mutex.Unlock()
+
+		for _, ev := range events {
+			select {
+			case <-sub.done:
+				return
+			default:
+				sub.send(ev)
+			}
+
+			sub.mutex.Lock()
+			sub.handled++
+			sub.cond.Broadcast()
+			sub.mutex.Unlock()
+		}
+	}
+}
+
+// send delivers ev according to the subscriber's policy.
+func (sub *subscriber[K, V]) send(ev Event[K, V]) {
+	if sub.fn != nil {
+		sub.fn(ev)
+		return
+	}
+
+	switch sub.policy {
+	case Block:
+		select {
+		case sub.ch <- ev:
+		case <-sub.done:
+		}
+	case DropNewest:
+		select {
+		case sub.ch <- ev:
+		default:
+		}
+	case DropOldest:
+		for {
+			select {
+			case sub.ch <- ev:
+				return
+			default:
+			}
+
+			// make room, unless the subscriber just drained it
+			select {
+			case <-sub.ch:
+			default:
+			}
+		}
+	}
+}
+
+// Subscribe returns a channel receiving every change made to the map
+// and a function to cancel the subscription. After cancel no further
+// events are sent and the channel is closed.
+//
+// buffer is the channel capacity and policy decides what happens when
+// it is full. Events are delivered in the order the changes were made,
+// and a write returns once its events have been sent or dropped.
+// Expiries found by reads (Get, DeleteExpired) do not wait.
+//
+// Events are sent by a goroutine that runs until cancel is called.
+func (s *HashMap[K, V]) Subscribe(buffer int, policy Policy) (<-chan Event[K, V], func()) {
+	if buffer < 0 {
+		buffer = 0
+	}
+
+	// DropOldest needs somewhere to drop from
+	if policy == DropOldest && buffer == 0 {
+		buffer = 1
+	}
+
+	sub := newSubscriber(make(chan Event[K, V], buffer), nil, policy)
+	return sub.ch, s.subscribe(sub)
+}
+
+// Watch registers fn to be called with every change made to the map
+// and returns a function to cancel the registration.
+//
+// fn is called in order on a goroutine owned by the registration, with
+// the map unlocked. A write returns once fn has handled its events.
+// fn may read the map and may cancel its own registration, but must
+// not write to the map. A call already in progress may still complete
+// after cancel returns.
+func (s *HashMap[K, V]) Watch(fn func(ev Event[K, V])) func() {
+	sub := newSubscriber(nil, fn, Block)
+	return s.subscribe(sub)
+}
+
+// adds sub to the subscribers and returns its cancel function.
+func (s *HashMap[K, V]) subscribe(sub *subscriber[K, V]) func() {
+	s.mutex.Lock()
+	// copy on write so record can iterate a stable slice
+	subs := make([]*subscriber[K, V], len(s.subs), len(s.subs)+1)
+	copy(subs, s.subs)
+	s.subs = append(subs, sub)
+	s.mutex.Unlock()
+
+	return func() {
+		sub.once.Do(func() {
+			// never waits on delivery, so fn may cancel itself
+			sub.cancel()
+			s.unsubscribe(sub)
+		})
+	}
+}
+
+// removes sub from the subscribers.
+func (s *HashMap[K, V]) unsubscribe(sub *subscriber[K, V]) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	subs := make([]*subscriber[K, V], 0, len(s.subs))
+	for _, other := range s.subs {
+		if other != sub {
+			subs = append(subs, other)
+		}
+	}
+	s.subs = subs
+}
+
+// record queues ev for every subscriber. Unless ev is an expiry,
+// unlock waits for it to be handled after releasing the lock.
+// Must be called with the write lock held.
+func (s *HashMap[K, V]) record(ev Event[K, V]) {
+	for _, sub := range s.subs {
+		ticket := sub.enqueue(ev)
+		if ev.Type == EventExpire {
+			continue
+		}
+
+		if s.tickets == nil {
+			s.tickets = make(map[*subscriber[K, V]]uint64)
+		}
+		s.tickets[sub] = ticket
+	}
+}
+
+// wait blocks until each subscriber has handled the event with its ticket.
+// Must be called without the lock held.
+func wait[K comparable, V any](tickets map[*subscriber[K, V]]uint64) {
+	for sub, ticket := range tickets {
+		sub.wait(ticket)
+	}
+}
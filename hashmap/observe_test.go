@@ -0,0 +1,279 @@
+package hashmap_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abiiranathan/algo/hashmap"
+)
+
+func TestMapSubscribe(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, int]()
+	m.Set("before", 0)
+
+	events, cancel := m.Subscribe(16, hashmap.Block)
+
+	m.Set("a", 1)
+	m.Set("a", 2)
+	m.Delete("a")
+	m.Delete("missing")
+	m.Compute("b", func(old int, ok bool) int { return 3 })
+	m.Clear()
+
+	want := []hashmap.Event[string, int]{
+		{Type: hashmap.EventSet, Key: "a", New: 1},
+		{Type: hashmap.EventSet, Key: "a", Old: 1, New: 2, HadOld: true},
+		{Type: hashmap.EventDelete, Key: "a", Old: 2, HadOld: true},
+		{Type: hashmap.EventSet, Key: "b", New: 3},
+		{Type: hashmap.EventClear},
+	}
+
+	for i, w := range want {
+		select {
+		case got := <-events:
+			if got != w {
+				t.Errorf("event %d: got %+v, want %+v", i, got, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event %d (%s) not delivered", i, w.Type)
+		}
+	}
+
+	cancel()
+	cancel()
+
+	m.Set("after", 1)
+	if _, ok := <-events; ok {
+		t.Error("channel should be closed after cancel")
+	}
+}
+
+func TestMapSubscribeExpire(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, int]()
+	events, cancel := m.Subscribe(4, hashmap.Block)
+	defer cancel()
+
+	m.SetWithTTL("token", 1, time.Millisecond)
+	<-events
+
+	time.Sleep(5 * time.Millisecond)
+	m.DeleteExpired()
+
+	if ev := <-events; ev.Type != hashmap.EventExpire || ev.Key != "token" {
+		t.Errorf("expected an expire event, got %+v", ev)
+	}
+}
+
+func TestMapSubscribePolicies(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, int]()
+
+	newest, cancelNewest := m.Subscribe(2, hashmap.DropNewest)
+	defer cancelNewest()
+
+	oldest, cancelOldest := m.Subscribe(2, hashmap.DropOldest)
+	defer cancelOldest()
+
+	for i := 0; i < 5; i++ {
+		m.Set(i, i)
+	}
+
+	if a, b := <-newest, <-newest; a.Key != 0 || b.Key != 1 {
+		t.Errorf("DropNewest should keep the first events, got %d and %d", a.Key, b.Key)
+	}
+
+	if a, b := <-oldest, <-oldest; a.Key != 3 || b.Key != 4 {
+		t.Errorf("DropOldest should keep the last events, got %d and %d", a.Key, b.Key)
+	}
+}
+
+func TestMapSubscribeCancelUnblocksWriter(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, int]()
+	_, cancel := m.Subscribe(0, hashmap.Block)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Set(1, 1) // blocks: nobody is reading
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("cancel should unblock a writer waiting on the subscriber")
+	}
+}
+
+func TestMapWatch(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, string]()
+
+	changes := []string{}
+	cancel := m.Watch(func(ev hashmap.Event[string, string]) {
+		// the map may be read from the callback
+		v, _ := m.Get(ev.Key)
+		changes = append(changes, ev.Type.String()+":"+ev.Key+"="+v)
+	})
+
+	m.Set("level", "debug")
+	m.LoadAndDelete("level")
+	cancel()
+	m.Set("level", "info")
+
+	if len(changes) != 2 || changes[0] != "set:level=debug" || changes[1] != "delete:level=" {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+}
+
+// runs fn in a goroutine and fails the test if it does not finish in time.
+func finishes(t *testing.T, name string, fn func()) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%s deadlocked", name)
+	}
+}
+
+func TestMapWatchReadsDuringWrites(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, int]()
+
+	cancel := m.Watch(func(ev hashmap.Event[int, int]) {
+		m.Get(ev.Key)
+		m.Len()
+	})
+	defer cancel()
+
+	finishes(t, "concurrent Set", func() {
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 500; i++ {
+					m.Set(g*1000+i%10, i)
+				}
+			}(g)
+		}
+		wg.Wait()
+	})
+}
+
+func TestMapSubscribeReadsDuringWrites(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, int]()
+	events, cancel := m.Subscribe(1, hashmap.Block)
+
+	consumed := make(chan struct{})
+	go func() {
+		defer close(consumed)
+		for ev := range events {
+			m.Get(ev.Key)
+		}
+	}()
+
+	finishes(t, "concurrent SetAll", func() {
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 200; i++ {
+					m.SetAll(map[int]int{g: i, g + 10: i})
+				}
+			}(g)
+		}
+		wg.Wait()
+	})
+
+	cancel()
+	<-consumed
+}
+
+func TestMapWatchCancelFromCallback(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, int]()
+
+	var mu sync.Mutex
+	calls := 0
+
+	var cancel func()
+	ready := make(chan struct{})
+	cancel = m.Watch(func(ev hashmap.Event[string, int]) {
+		<-ready
+		mu.Lock()
+		calls++
+		mu.Unlock()
+
+		// one-shot watcher
+		cancel()
+	})
+	close(ready)
+
+	finishes(t, "self-cancelling watcher", func() {
+		m.Set("a", 1)
+		m.Set("b", 2)
+		m.Set("c", 3)
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 1 {
+		t.Errorf("expected the watcher to run once, got %d", calls)
+	}
+}
+
+func TestMapWatchLazyExpiry(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, int]()
+	m.SetWithTTL("token", 1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	cancel := m.Watch(func(ev hashmap.Event[string, int]) {
+		// expires the token while handling an event
+		m.Get("token")
+	})
+	defer cancel()
+
+	finishes(t, "lazy expiry from a watcher", func() {
+		m.Set("other", 1)
+	})
+}
+
+func TestMapSubscribeOverwriteExpired(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, int]()
+	events, cancel := m.Subscribe(4, hashmap.Block)
+	defer cancel()
+
+	m.SetWithTTL("token", 1, time.Millisecond)
+	<-events
+	time.Sleep(5 * time.Millisecond)
+
+	m.Set("token", 2)
+
+	want := []hashmap.Event[string, int]{
+		{Type: hashmap.EventExpire, Key: "token", Old: 1, HadOld: true},
+		{Type: hashmap.EventSet, Key: "token", New: 2},
+	}
+	for i, w := range want {
+		if got := <-events; got != w {
+			t.Errorf("event %d: got %+v, want %+v", i, got, w)
+		}
+	}
+}
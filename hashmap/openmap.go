@@ -0,0 +1,275 @@
+package hashmap
+
+import (
+	"bytes"
+	"sync"
+)
+
+// initial number of slots in an OpenMap. Always a power of two.
+const openMapMinSlots = 8
+
+// slot is a single bucket of an OpenMap.
+// psl is the probe sequence length plus one, so 0 marks an empty slot.
+type slot[K any, V any] struct {
+	key   K
+	value V
+	hash  uint64
+	psl   uint32
+}
+
+// OpenMap is an open-addressing hash table using Robin Hood hashing.
+//
+// Unlike HashMap, keys need not be comparable: hashing and equality
+// are supplied by the caller, so slices and structs containing slices
+// can be used as keys.
+//
+// Robin Hood hashing keeps probe sequences short by letting an inserted
+// key displace a resident key that is closer to its home slot.
+// Deletion uses backward shifting so no tombstones are needed.
+//
+// Like HashMap, it is guarded by a sync.RWMutex.
+//
+// The map keeps a copy of []byte keys, so callers may reuse the slice
+// after Set. Other keys that share memory with the caller (structs
+// holding slices or pointers) must not be modified after insertion, or
+// they no longer match their hash and lookups fail.
+type OpenMap[K any, V any] struct {
+	slots []slot[K, V]
+	count int
+	mask  uint64
+	hash  Hasher[K]
+	equal func(a, b K) bool
+	mutex *sync.RWMutex
+}
+
+// Instantiates a new OpenMap using hash and equal to compare keys.
+func NewOpenMap[K any, V any](hash Hasher[K], equal func(a, b K) bool) *OpenMap[K, V] {
+	return &OpenMap[K, V]{
+		slots: make([]slot[K, V], openMapMinSlots),
+		mask:  openMapMinSlots - 1,
+		hash:  hash,
+		equal: equal,
+		mutex: &sync.RWMutex{},
+	}
+}
+
+// find returns the slot index of key or -1 if not present.
+func (m *OpenMap[K, V]) find(key K) int {
+	h := m.hash(key)
+	i := h & m.mask
+
+	for psl := uint32(1); ; psl++ {
+		s := &m.slots[i]
+
+		// an empty slot or a richer resident means key is absent
+		if s.psl < psl {
+			return -1
+		}
+
+		if s.hash == h && m.equal(s.key, key) {
+			return int(i)
+		}
+		i = (i + 1) & m.mask
+	}
+}
+
+// insert places a key known to be absent into the table.
+func (m *OpenMap[K, V]) insert(h uint64, key K, value V) {
+	cur := slot[K, V]{key: key, value: value, hash: h, psl: 1}
+	i := h & m.mask
+
+	for {
+		s := &m.slots[i]
+		if s.psl == 0 {
+			*s = cur
+			m.count++
+			return
+		}
+
+		// take from the rich: displace keys closer to home
+		if s.psl < cur.psl {
+			*s, cur = cur, *s
+		}
+
+		cur.psl++
+		i = (i + 1) & m.mask
+	}
+}
+
+// grow doubles the number of slots and reinserts every key.
+func (m *OpenMap[K, V]) grow() {
+	old := m.slots
+	m.slots = make([]slot[K, V], len(old)*2)
+	m.mask = uint64(len(m.slots) - 1)
+	m.count = 0
+
+	for _, s := range old {
+		if s.psl != 0 {
+			m.insert(s.hash, s.key, s.value)
+		}
+	}
+}
+
+// Returns V and true if key in map.
+func (m *OpenMap[K, V]) Get(key K) (value V, ok bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	i := m.find(key)
+	if i < 0 {
+		return value, false
+	}
+	return m.slots[i].value, true
+}
+
+// Inserts V in map under the key.
+// A []byte key is copied; see OpenMap for other keys.
+func (m *OpenMap[K, V]) Set(key K, value V) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if i := m.find(key); i >= 0 {
+		m.slots[i].value = value
+		return
+	}
+
+	// detach byte slice keys from the caller's buffer
+	if b, ok := any(key).([]byte); ok {
+		key = any(bytes.Clone(b)).(K)
+	}
+
+	// keep the load factor at or below 7/8
+	if (m.count+1)*8 > len(m.slots)*7 {
+		m.grow()
+	}
+	m.insert(m.hash(key), key, value)
+}
+
+// deletes the element with the specified key
+func (m *OpenMap[K, V]) Delete(key K) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	i := m.find(key)
+	if i < 0 {
+		return
+	}
+
+	// shift following keys back towards their home slots
+	cur := uint64(i)
+	next := (cur + 1) & m.mask
+	for m.slots[next].psl > 1 {
+		m.slots[cur] = m.slots[next]
+		m.slots[cur].psl--
+		cur = next
+		next = (next + 1) & m.mask
+	}
+
+	m.slots[cur] = slot[K, V]{}
+	m.count--
+}
+
+// deletes all elements in the map
+func (m *OpenMap[K, V]) Clear() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.slots = make([]slot[K, V], openMapMinSlots)
+	m.mask = openMapMinSlots - 1
+	m.count = 0
+}
+
+// Returns true if key in map
+func (m *OpenMap[K, V]) Contains(key K) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.find(key) >= 0
+}
+
+// Returns the number of elements in the map
+func (m *OpenMap[K, V]) Len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.count
+}
+
+// Returns true is map has zero elements
+func (m *OpenMap[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Returns a slice of the keys in the map
+func (m *OpenMap[K, V]) Keys() []K {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	keys := make([]K, 0, m.count)
+	for _, s := range m.slots {
+		if s.psl != 0 {
+			keys = append(keys, s.key)
+		}
+	}
+	return keys
+}
+
+// Returns a slice of all the values in the map
+func (m *OpenMap[K, V]) Values() []V {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	values := make([]V, 0, m.count)
+	for _, s := range m.slots {
+		if s.psl != 0 {
+			values = append(values, s.value)
+		}
+	}
+	return values
+}
+
+// Range calls fn for each key and value in the map until fn returns false.
+//
+// It iterates over a snapshot taken under a single lock, so fn may
+// safely access or modify the map.
+func (m *OpenMap[K, V]) Range(fn func(key K, value V) bool) {
+	m.mutex.RLock()
+	slots := make([]slot[K, V], 0, m.count)
+	for _, s := range m.slots {
+		if s.psl != 0 {
+			slots = append(slots, s)
+		}
+	}
+	m.mutex.RUnlock()
+
+	for _, s := range slots {
+		if !fn(s.key, s.value) {
+			return
+		}
+	}
+}
+
+// ProbeStats reports the mean and longest probe sequence length
+// over all keys, useful when comparing hash functions.
+// A key in its home slot has a probe length of 1.
+func (m *OpenMap[K, V]) ProbeStats() (mean float64, longest int) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if m.count == 0 {
+		return 0, 0
+	}
+
+	total := 0
+	for _, s := range m.slots {
+		if s.psl == 0 {
+			continue
+		}
+
+		total += int(s.psl)
+		if int(s.psl) > longest {
+			longest = int(s.psl)
+		}
+	}
+	return float64(total) / float64(m.count), longest
+}
@@ -0,0 +1,196 @@
+package hashmap_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/maphash"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/abiiranathan/algo/hashmap"
+)
+
+func TestOpenMapBytesKeys(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewOpenMap[[]byte, int](hashmap.BytesHasher(), bytes.Equal)
+
+	m.Set([]byte("alpha"), 1)
+	m.Set([]byte("beta"), 2)
+	m.Set([]byte("alpha"), 3)
+
+	if m.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", m.Len())
+	}
+
+	if v, ok := m.Get([]byte("alpha")); !ok || v != 3 {
+		t.Error("Set should overwrite an equal key")
+	}
+
+	m.Delete([]byte("alpha"))
+	if m.Contains([]byte("alpha")) || !m.Contains([]byte("beta")) {
+		t.Error("Delete failed")
+	}
+
+	// the map keeps its own copy of the key
+	buf := []byte("gamma")
+	m.Set(buf, 4)
+	copy(buf, "delta")
+	if v, ok := m.Get([]byte("gamma")); !ok || v != 4 {
+		t.Error("modifying the caller's slice should not affect the stored key")
+	}
+
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Error("Clear should empty the map")
+	}
+}
+
+func TestOpenMapRangeReadsDuringWrites(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewOpenMap[string, int](hashmap.StringHasher(), func(a, b string) bool { return a == b })
+	for i := 0; i < 100; i++ {
+		m.Set(strconv.Itoa(i), i)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				m.Set(strconv.Itoa(i%100), i)
+			}
+		}
+	}()
+
+	finishes(t, "reading from OpenMap.Range", func() {
+		for i := 0; i < 50; i++ {
+			m.Range(func(k string, v int) bool {
+				m.Get(k)
+				return true
+			})
+		}
+	})
+}
+
+// a key type that is not comparable
+type point struct {
+	tags []string
+	x, y int
+}
+
+func TestOpenMapStructKeys(t *testing.T) {
+	t.Parallel()
+	seed := maphash.MakeSeed()
+	hash := func(p point) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		binary.Write(&h, binary.LittleEndian, [2]int64{int64(p.x), int64(p.y)})
+		for _, tag := range p.tags {
+			h.WriteString(tag)
+			h.WriteByte(0)
+		}
+		return h.Sum64()
+	}
+
+	equal := func(a, b point) bool {
+		if a.x != b.x || a.y != b.y || len(a.tags) != len(b.tags) {
+			return false
+		}
+		for i := range a.tags {
+			if a.tags[i] != b.tags[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	m := hashmap.NewOpenMap[point, string](hash, equal)
+	m.Set(point{[]string{"a"}, 1, 2}, "first")
+
+	if v, ok := m.Get(point{[]string{"a"}, 1, 2}); !ok || v != "first" {
+		t.Error("lookup with an equal struct key failed")
+	}
+
+	if m.Contains(point{[]string{"b"}, 1, 2}) {
+		t.Error("different tags should be a different key")
+	}
+}
+
+func TestOpenMapRandomized(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewOpenMap[int, int](hashmap.ComparableHasher[int](), func(a, b int) bool {
+		return a == b
+	})
+	ref := map[int]int{}
+	r := rand.New(rand.NewSource(7))
+
+	for i := 0; i < 20000; i++ {
+		key := r.Intn(2000)
+		if r.Intn(3) == 0 {
+			m.Delete(key)
+			delete(ref, key)
+		} else {
+			m.Set(key, i)
+			ref[key] = i
+		}
+	}
+
+	if m.Len() != len(ref) || len(m.Keys()) != len(ref) || len(m.Values()) != len(ref) {
+		t.Fatalf("expected %d entries, got %d", len(ref), m.Len())
+	}
+
+	for k, v := range ref {
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Fatalf("Get(%d) = %d, %v; want %d", k, got, ok, v)
+		}
+	}
+
+	n := 0
+	m.Range(func(k, v int) bool {
+		n++
+		return ref[k] == v
+	})
+
+	if n != len(ref) {
+		t.Errorf("Range should visit %d entries, got %d", len(ref), n)
+	}
+
+	mean, longest := m.ProbeStats()
+	if mean < 1 || longest < 1 {
+		t.Errorf("unexpected probe stats %f %d", mean, longest)
+	}
+}
+
+func BenchmarkOpenMapBytes(b *testing.B) {
+	m := hashmap.NewOpenMap[[]byte, int](hashmap.BytesHasher(), bytes.Equal)
+	keys := make([][]byte, 1024)
+	for i := range keys {
+		keys[i] = []byte(strconv.Itoa(i))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := keys[i%len(keys)]
+		m.Set(key, i)
+		m.Get(key)
+	}
+}
+
+func BenchmarkHashMapStringFromBytes(b *testing.B) {
+	m := hashmap.NewHashMap[string, int]()
+	keys := make([][]byte, 1024)
+	for i := range keys {
+		keys[i] = []byte(strconv.Itoa(i))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := keys[i%len(keys)]
+		m.Set(string(key), i)
+		m.Get(string(key))
+	}
+}
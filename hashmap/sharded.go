@@ -0,0 +1,232 @@
+package hashmap
+
+import (
+	"hash/maphash"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ShardedHashMap spreads its keys over a number of independently locked
+// HashMap shards so that writers to different shards do not contend.
+//
+// Keys are assigned to shards with hash/maphash. Prefer it over HashMap
+// when there are many concurrent writes.
+//
+// Operations that span all shards (Keys, Values, Len, Clear) lock one
+// shard at a time and therefore do not observe a single point in time.
+type ShardedHashMap[K comparable, V any] struct {
+	shards []*HashMap[K, V]
+	mask   uint64
+	seed   maphash.Seed
+
+	mutex   *sync.Mutex // guards janitor
+	janitor *janitor
+}
+
+// Instantiates a new ShardedHashMap with the given number of shards,
+// rounded up to a power of two. If shards <= 0, a default based on
+// runtime.GOMAXPROCS is used.
+func NewShardedHashMap[K comparable, V any](shards int) *ShardedHashMap[K, V] {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0) * 4
+	}
+
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+
+	s := &ShardedHashMap[K, V]{
+		shards: make([]*HashMap[K, V], n),
+		mask:   uint64(n - 1),
+		seed:   maphash.MakeSeed(),
+		mutex:  &sync.Mutex{},
+	}
+
+	for i := range s.shards {
+		s.shards[i] = NewHashMap[K, V]()
+	}
+	return s
+}
+
+// returns the index of the shard responsible for key
+func (s *ShardedHashMap[K, V]) index(key K) uint64 {
+	return maphash.Comparable(s.seed, key) & s.mask
+}
+
+// returns the shard responsible for key
+func (s *ShardedHashMap[K, V]) shard(key K) *HashMap[K, V] {
+	return s.shards[s.index(key)]
+}
+
+// Returns the number of shards
+func (s *ShardedHashMap[K, V]) Shards() int {
+	return len(s.shards)
+}
+
+// Returns V and true if key in map.
+func (s *ShardedHashMap[K, V]) Get(key K) (V, bool) {
+	return s.shard(key).Get(key)
+}
+
+// Inserts V in map under the key
+func (s *ShardedHashMap[K, V]) Set(key K, value V) {
+	s.shard(key).Set(key, value)
+}
+
+// deletes the element with the specified key
+func (s *ShardedHashMap[K, V]) Delete(key K) {
+	s.shard(key).Delete(key)
+}
+
+// deletes all elements in the map
+func (s *ShardedHashMap[K, V]) Clear() {
+	for _, shard := range s.shards {
+		shard.Clear()
+	}
+}
+
+// Returns a slice of the keys in the map
+func (s *ShardedHashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, s.Len())
+	for _, shard := range s.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// Returns a slice of all the values in the map
+func (s *ShardedHashMap[K, V]) Values() []V {
+	values := make([]V, 0, s.Len())
+	for _, shard := range s.shards {
+		values = append(values, shard.Values()...)
+	}
+	return values
+}
+
+// Returns the number of elements in the map
+func (s *ShardedHashMap[K, V]) Len() int {
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Returns true is map has zero elements
+func (s *ShardedHashMap[K, V]) IsEmpty() bool {
+	for _, shard := range s.shards {
+		if !shard.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if key in map
+func (s *ShardedHashMap[K, V]) Contains(key K) bool {
+	return s.shard(key).Contains(key)
+}
+
+// Returns the existing value for key if present.
+// Otherwise stores and returns value.
+// loaded is true if the value was loaded, false if stored.
+func (s *ShardedHashMap[K, V]) GetOrSet(key K, value V) (actual V, loaded bool) {
+	return s.shard(key).GetOrSet(key, value)
+}
+
+// Like GetOrSet but the value is only created by calling fn if key is absent.
+// fn is called while the shard lock is held and must not access the map.
+func (s *ShardedHashMap[K, V]) GetOrCompute(key K, fn func() V) (actual V, loaded bool) {
+	return s.shard(key).GetOrCompute(key, fn)
+}
+
+// Deletes the value for key, returning the previous value if any.
+// loaded is true if the key was present.
+func (s *ShardedHashMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	return s.shard(key).LoadAndDelete(key)
+}
+
+// Update atomically replaces the value under key with the result of fn.
+// See HashMap.Update.
+func (s *ShardedHashMap[K, V]) Update(key K, fn func(old V, ok bool) (value V, keep bool)) (V, bool) {
+	return s.shard(key).Update(key, fn)
+}
+
+// Compute atomically stores the result of fn under key and returns it.
+// See HashMap.Compute.
+func (s *ShardedHashMap[K, V]) Compute(key K, fn func(old V, ok bool) V) V {
+	return s.shard(key).Compute(key, fn)
+}
+
+// CompareAndSwap for a ShardedHashMap.
+// Swaps the value under key for new if the current value equals old.
+func CompareAndSwapSharded[K comparable, V comparable](s *ShardedHashMap[K, V], key K, old, new V) bool {
+	return CompareAndSwap(s.shard(key), key, old, new)
+}
+
+// CompareAndDelete for a ShardedHashMap.
+// Deletes the entry for key if its value equals old.
+func CompareAndDeleteSharded[K comparable, V comparable](s *ShardedHashMap[K, V], key K, old V) bool {
+	return CompareAndDelete(s.shard(key), key, old)
+}
+
+// Inserts V in map under the key, expiring after ttl.
+// See HashMap.SetWithTTL.
+func (s *ShardedHashMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	s.shard(key).SetWithTTL(key, value, ttl)
+}
+
+// Sets the TTL applied to entries written without an explicit TTL on every shard.
+func (s *ShardedHashMap[K, V]) SetDefaultTTL(ttl time.Duration) {
+	for _, shard := range s.shards {
+		shard.SetDefaultTTL(ttl)
+	}
+}
+
+// Registers fn to be called with every entry removed because it expired.
+func (s *ShardedHashMap[K, V]) OnEvict(fn func(key K, value V)) {
+	for _, shard := range s.shards {
+		shard.OnEvict(fn)
+	}
+}
+
+// Removes all expired entries, returning the number removed.
+func (s *ShardedHashMap[K, V]) DeleteExpired() int {
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.DeleteExpired()
+	}
+	return n
+}
+
+// Returns the remaining time to live of key. See HashMap.TTL.
+func (s *ShardedHashMap[K, V]) TTL(key K) (time.Duration, bool) {
+	return s.shard(key).TTL(key)
+}
+
+// Starts a single background goroutine that calls DeleteExpired every
+// interval. Any janitor already running is stopped first. Call Close to stop it.
+//
+// An interval <= 0 disables the janitor: a running janitor is stopped
+// and no new one is started.
+func (s *ShardedHashMap[K, V]) StartJanitor(interval time.Duration) {
+	var j *janitor
+	if interval > 0 {
+		j = startJanitor(interval, func() { s.DeleteExpired() })
+	}
+
+	s.mutex.Lock()
+	old := s.janitor
+	s.janitor = j
+	s.mutex.Unlock()
+
+	old.halt()
+}
+
+// Stops the background janitor, if any, and waits for it to exit.
+// It is safe to call Close more than once.
+func (s *ShardedHashMap[K, V]) Close() {
+	s.StartJanitor(0)
+}
@@ -0,0 +1,244 @@
+package hashmap_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abiiranathan/algo/hashmap"
+)
+
+func TestShardedMap(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewShardedHashMap[string, int](5)
+
+	if m.Shards() != 8 {
+		t.Errorf("shard count should be rounded up to 8, got %d", m.Shards())
+	}
+
+	if !m.IsEmpty() {
+		t.Error("new map should be empty")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Set(strconv.Itoa(i*100+j), j)
+				m.Compute("total", func(old int, ok bool) int {
+					return old + 1
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if m.Len() != 3201 {
+		t.Errorf("expected 3201 entries, got %d", m.Len())
+	}
+
+	if v, _ := m.Get("total"); v != 3200 {
+		t.Errorf("Compute should be atomic across goroutines, got %d", v)
+	}
+
+	if len(m.Keys()) != 3201 || len(m.Values()) != 3201 {
+		t.Error("Keys and Values should cover all shards")
+	}
+
+	if !hashmap.CompareAndSwapSharded(m, "total", 3200, 0) {
+		t.Error("CompareAndSwapSharded failed")
+	}
+
+	if !hashmap.CompareAndDeleteSharded(m, "total", 0) || m.Contains("total") {
+		t.Error("CompareAndDeleteSharded failed")
+	}
+
+	if v, loaded := m.LoadAndDelete("0"); !loaded || v != 0 {
+		t.Error("LoadAndDelete failed")
+	}
+
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Error("Clear should empty every shard")
+	}
+}
+
+func TestShardedMapTTL(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewShardedHashMap[int, int](4)
+
+	var mu sync.Mutex
+	evicted := 0
+	m.OnEvict(func(key, value int) {
+		mu.Lock()
+		defer mu.Unlock()
+		evicted++
+	})
+
+	for i := 0; i < 8; i++ {
+		m.SetWithTTL(i, i, time.Millisecond)
+	}
+	m.SetWithTTL(100, 100, time.Hour)
+
+	if ttl, ok := m.TTL(100); !ok || ttl <= 0 {
+		t.Error("TTL should report the remaining time")
+	}
+
+	if _, ok := m.TTL(200); ok {
+		t.Error("TTL should report missing keys as having no expiry")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if n := m.DeleteExpired(); n != 8 {
+		t.Errorf("DeleteExpired should remove 8 entries across shards, got %d", n)
+	}
+
+	m.SetDefaultTTL(time.Millisecond)
+	m.Set(300, 300)
+	time.Sleep(5 * time.Millisecond)
+	if m.Contains(300) {
+		t.Error("entries should expire after the default TTL on every shard")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if evicted != 8 {
+		t.Errorf("expected 8 evictions, got %d", evicted)
+	}
+}
+
+func TestShardedMapJanitor(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewShardedHashMap[int, int](4)
+
+	evicted := make(chan int, 16)
+	m.OnEvict(func(key, value int) {
+		evicted <- key
+	})
+
+	m.StartJanitor(0) // must not panic
+	m.StartJanitor(5 * time.Millisecond)
+	defer m.Close()
+
+	for i := 0; i < 8; i++ {
+		m.SetWithTTL(i, i, time.Millisecond)
+	}
+
+	for i := 0; i < 8; i++ {
+		select {
+		case <-evicted:
+		case <-time.After(time.Second):
+			t.Fatalf("janitor evicted only %d of 8 entries", i)
+		}
+	}
+
+	m.Close()
+	m.Close()
+
+	m.SetWithTTL(1, 1, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case <-evicted:
+		t.Error("janitor should be stopped after Close")
+	default:
+	}
+}
+
+// keys shared by the write benchmarks
+var benchKeys = func() []string {
+	keys := make([]string, 1024)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	return keys
+}()
+
+func BenchmarkHashMapWrite(b *testing.B) {
+	m := hashmap.NewHashMap[string, int]()
+	b.SetParallelism(32)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Set(benchKeys[i%len(benchKeys)], i)
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedHashMapWrite(b *testing.B) {
+	m := hashmap.NewShardedHashMap[string, int](0)
+	b.SetParallelism(32)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Set(benchKeys[i%len(benchKeys)], i)
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapWrite(b *testing.B) {
+	var m sync.Map
+	b.SetParallelism(32)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Store(benchKeys[i%len(benchKeys)], i)
+			i++
+		}
+	})
+}
+
+func BenchmarkHashMapReadWrite(b *testing.B) {
+	m := hashmap.NewHashMap[string, int]()
+	b.SetParallelism(32)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := benchKeys[i%len(benchKeys)]
+			if i%4 == 0 {
+				m.Set(key, i)
+			} else {
+				m.Get(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedHashMapReadWrite(b *testing.B) {
+	m := hashmap.NewShardedHashMap[string, int](0)
+	b.SetParallelism(32)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := benchKeys[i%len(benchKeys)]
+			if i%4 == 0 {
+				m.Set(key, i)
+			} else {
+				m.Get(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapReadWrite(b *testing.B) {
+	var m sync.Map
+	b.SetParallelism(32)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := benchKeys[i%len(benchKeys)]
+			if i%4 == 0 {
+				m.Store(key, i)
+			} else {
+				m.Load(key)
+			}
+			i++
+		}
+	})
+}
@@ -0,0 +1,246 @@
+package hashmap
+
+import (
+	"time"
+)
+
+// entry is a key-value pair removed from the map.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// janitor periodically removes expired entries in the background.
+type janitor struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Inserts V in map under the key, expiring after ttl.
+// A ttl <= 0 stores the value without expiry.
+//
+// Set and SetAll replace the ttl with the default TTL of the map.
+// Read-modify-write operations (Update, Compute, CompareAndSwap)
+// keep the existing expiry.
+func (s *HashMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	s.lock()
+	defer s.unlock()
+
+	s.store(key, value, ttl)
+}
+
+// Sets the TTL applied to entries written without an explicit TTL.
+// A ttl <= 0 (the default) disables expiry. Existing entries are not affected.
+func (s *HashMap[K, V]) SetDefaultTTL(ttl time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.defaultTTL = ttl
+}
+
+// Registers fn to be called with every entry removed because it expired.
+// fn is called after the lock is released so it may access the map.
+func (s *HashMap[K, V]) OnEvict(fn func(key K, value V)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.onEvict = fn
+}
+
+// Returns the remaining time to live of key.
+// ok is false if key is not in the map or has no expiry.
+func (s *HashMap[K, V]) TTL(key K) (ttl time.Duration, ok bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	exp, ok := s.expires[key]
+	if !ok {
+		return 0, false
+	}
+
+	ttl = time.Until(exp)
+	if ttl <= 0 {
+		return 0, false
+	}
+	return ttl, true
+}
+
+// Removes all expired entries, returning the number removed.
+func (s *HashMap[K, V]) DeleteExpired() int {
+	s.lock()
+	defer s.unlock()
+
+	now := time.Now()
+	n := 0
+	for k := range s.expires {
+		if _, ok := s.load(k, now); !ok {
+			n++
+		}
+	}
+	return n
+}
+
+// Starts a background goroutine that calls DeleteExpired every interval.
+// Any janitor already running is stopped first. Call Close to stop it.
+//
+// An interval <= 0 disables the janitor: a running janitor is stopped
+// and no new one is started.
+func (s *HashMap[K, V]) StartJanitor(interval time.Duration) {
+	if interval <= 0 {
+		s.Close()
+		return
+	}
+
+	j := startJanitor(interval, func() { s.DeleteExpired() })
+
+	s.mutex.Lock()
+	old := s.janitor
+	s.janitor = j
+	s.mutex.Unlock()
+
+	old.halt()
+}
+
+// Stops the background janitor, if any, and waits for it to exit.
+// It is safe to call Close more than once.
+func (s *HashMap[K, V]) Close() {
+	s.mutex.Lock()
+	j := s.janitor
+	s.janitor = nil
+	s.mutex.Unlock()
+
+	j.halt()
+}
+
+// startJanitor starts a goroutine calling sweep every interval.
+func startJanitor(interval time.Duration, sweep func()) *janitor {
+	j := &janitor{stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(j.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				sweep()
+			case <-j.stop:
+				return
+			}
+		}
+	}()
+	return j
+}
+
+// halt stops the janitor goroutine and waits for it to exit.
+// It is a no-op on a nil janitor.
+func (j *janitor) halt() {
+	if j == nil {
+		return
+	}
+	close(j.stop)
+	<-j.done
+}
+
+// lock acquires the write lock. It must be paired with unlock.
+func (s *HashMap[K, V]) lock() {
+	s.mutex.Lock()
+}
+
+// unlock releases the write lock, then waits for subscribers to handle
+// the changes and runs the eviction callback for entries that expired
+// while it was held.
+func (s *HashMap[K, V]) unlock() {
+	evicted, fn := s.evicted, s.onEvict
+	tickets := s.tickets
+	s.evicted, s.tickets = nil, nil
+	s.mutex.Unlock()
+
+	wait(tickets)
+
+	if fn == nil {
+		return
+	}
+
+	for _, e := range evicted {
+		fn(e.key, e.value)
+	}
+}
+
+// expired reports whether key has an expiry at or before now.
+// Must be called with the lock held.
+func (s *HashMap[K, V]) expired(key K, now time.Time) bool {
+	exp, ok := s.expires[key]
+	return ok && !now.Before(exp)
+}
+
+// load returns the live value under key, removing it if it has expired.
+// Must be called with the write lock held.
+func (s *HashMap[K, V]) load(key K, now time.Time) (V, bool) {
+	v, ok := s.m[key]
+	if !ok {
+		return v, false
+	}
+
+	if s.expired(key, now) {
+		delete(s.m, key)
+		delete(s.expires, key)
+		s.evicted = append(s.evicted, entry[K, V]{key, v})
+		s.record(Event[K, V]{Type: EventExpire, Key: key, Old: v, HadOld: true})
+
+		var zero V
+		return zero, false
+	}
+	return v, true
+}
+
+// store sets key to value, expiring after ttl if ttl > 0.
+// An expired entry under key is evicted first.
+// Must be called with the write lock held.
+func (s *HashMap[K, V]) store(key K, value V, ttl time.Duration) {
+	old, had := s.load(key, time.Now())
+	s.m[key] = value
+	if ttl > 0 {
+		s.expires[key] = time.Now().Add(ttl)
+	} else {
+		delete(s.expires, key)
+	}
+	s.record(Event[K, V]{Type: EventSet, Key: key, Old: old, New: value, HadOld: had})
+}
+
+// modify sets key to value, keeping the expiry of a live entry.
+// New keys get the default TTL.
+// Must be called with the write lock held.
+func (s *HashMap[K, V]) modify(key K, value V) {
+	if _, ok := s.load(key, time.Now()); !ok {
+		s.store(key, value, s.defaultTTL)
+		return
+	}
+
+	exp, ok := s.expires[key]
+	s.store(key, value, 0)
+	if ok {
+		s.expires[key] = exp
+	}
+}
+
+// remove deletes key whose current value is old.
+// Must be called with the write lock held.
+func (s *HashMap[K, V]) remove(key K, old V) {
+	delete(s.m, key)
+	delete(s.expires, key)
+	s.record(Event[K, V]{Type: EventDelete, Key: key, Old: old, HadOld: true})
+}
+
+// len returns the number of unexpired entries.
+// Must be called with the lock held.
+func (s *HashMap[K, V]) len(now time.Time) int {
+	n := len(s.m)
+	for k := range s.expires {
+		if s.expired(k, now) {
+			n--
+		}
+	}
+	return n
+}
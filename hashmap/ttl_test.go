@@ -0,0 +1,196 @@
+package hashmap_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abiiranathan/algo/hashmap"
+)
+
+func TestMapSetWithTTL(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, string]()
+
+	var mu sync.Mutex
+	evicted := []string{}
+	m.OnEvict(func(key, value string) {
+		mu.Lock()
+		defer mu.Unlock()
+		evicted = append(evicted, key)
+
+		// callbacks run without the lock held
+		m.Contains(key)
+	})
+
+	m.SetWithTTL("token", "abc", 20*time.Millisecond)
+	m.Set("forever", "xyz")
+
+	if ttl, ok := m.TTL("token"); !ok || ttl <= 0 {
+		t.Error("TTL should report the remaining time")
+	}
+
+	if _, ok := m.TTL("forever"); ok {
+		t.Error("TTL should report no expiry for plain entries")
+	}
+
+	if v, ok := m.Get("token"); !ok || v != "abc" {
+		t.Error("entry should be readable before it expires")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if m.Contains("token") || m.Len() != 1 || len(m.Keys()) != 1 || len(m.Values()) != 1 {
+		t.Error("expired entries should be hidden")
+	}
+
+	if _, ok := m.Get("token"); ok {
+		t.Error("Get should not return an expired entry")
+	}
+
+	mu.Lock()
+	if len(evicted) != 1 || evicted[0] != "token" {
+		t.Errorf("expected token to be evicted, got %v", evicted)
+	}
+	mu.Unlock()
+
+	if v, loaded := m.GetOrSet("token", "def"); loaded || v != "def" {
+		t.Error("GetOrSet should treat an expired key as missing")
+	}
+}
+
+func TestMapDefaultTTL(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, int]()
+	m.SetDefaultTTL(10 * time.Millisecond)
+
+	m.Set(1, 1)
+	m.SetWithTTL(2, 2, 0)
+
+	time.Sleep(20 * time.Millisecond)
+
+	if m.Contains(1) {
+		t.Error("entry should expire after the default TTL")
+	}
+
+	if !m.Contains(2) {
+		t.Error("entry set with ttl <= 0 should not expire")
+	}
+
+	if n := m.DeleteExpired(); n != 1 {
+		t.Errorf("DeleteExpired should remove 1 entry, got %d", n)
+	}
+}
+
+func TestMapJanitor(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, int]()
+
+	evicted := make(chan int, 10)
+	m.OnEvict(func(key, value int) {
+		evicted <- key
+	})
+
+	m.StartJanitor(5 * time.Millisecond)
+	defer m.Close()
+
+	m.SetWithTTL(1, 1, time.Millisecond)
+
+	select {
+	case key := <-evicted:
+		if key != 1 {
+			t.Errorf("expected key 1 to be evicted, got %d", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("janitor did not evict the expired entry")
+	}
+
+	m.Close()
+	m.Close()
+}
+
+func TestMapOverwriteExpired(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, int]()
+
+	var mu sync.Mutex
+	evicted := []string{}
+	m.OnEvict(func(key string, value int) {
+		mu.Lock()
+		defer mu.Unlock()
+		evicted = append(evicted, key)
+	})
+
+	m.SetWithTTL("a", 1, time.Millisecond)
+	m.SetWithTTL("b", 1, time.Millisecond)
+	m.SetWithTTL("c", 1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	// overwriting an expired entry must evict it first
+	m.Set("a", 2)
+	m.SetWithTTL("b", 2, time.Hour)
+	m.SetAll(map[string]int{"c": 2})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(evicted) != 3 {
+		t.Errorf("expected 3 evictions, got %v", evicted)
+	}
+}
+
+func TestMapReadModifyWriteKeepsTTL(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[string, int]()
+
+	m.SetWithTTL("token", 1, time.Hour)
+	m.Compute("token", func(old int, ok bool) int { return old + 1 })
+	m.Update("token", func(old int, ok bool) (int, bool) { return old + 1, true })
+	hashmap.CompareAndSwap(m, "token", 3, 4)
+
+	if v, _ := m.Get("token"); v != 4 {
+		t.Errorf("expected 4, got %d", v)
+	}
+
+	if ttl, ok := m.TTL("token"); !ok || ttl <= 59*time.Minute {
+		t.Errorf("read-modify-write should keep the expiry, got %v %v", ttl, ok)
+	}
+
+	// new keys get the default TTL
+	m.SetDefaultTTL(time.Minute)
+	m.Compute("new", func(old int, ok bool) int { return 1 })
+	if ttl, ok := m.TTL("new"); !ok || ttl > time.Minute {
+		t.Errorf("new key should get the default TTL, got %v %v", ttl, ok)
+	}
+
+	// plain Set replaces the expiry with the default TTL
+	m.SetDefaultTTL(0)
+	m.Set("token", 5)
+	if _, ok := m.TTL("token"); ok {
+		t.Error("Set should replace the expiry with the default TTL")
+	}
+}
+
+func TestMapJanitorInterval(t *testing.T) {
+	t.Parallel()
+	m := hashmap.NewHashMap[int, int]()
+
+	evicted := make(chan int, 10)
+	m.OnEvict(func(key, value int) {
+		evicted <- key
+	})
+
+	// a non-positive interval must not panic and stops a running janitor
+	m.StartJanitor(0)
+	m.StartJanitor(time.Millisecond)
+	m.StartJanitor(-time.Second)
+	defer m.Close()
+
+	m.SetWithTTL(1, 1, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	select {
+	case <-evicted:
+		t.Fatal("janitor should have been stopped")
+	default:
+	}
+}
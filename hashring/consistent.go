@@ -0,0 +1,164 @@
+package hashring
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DefaultReplicas is the number of virtual nodes per unit of weight
+// used when none is given to NewConsistent.
+const DefaultReplicas = 160
+
+// point is a virtual node on the ring.
+type point struct {
+	hash uint64
+	node string
+}
+
+// Consistent is a consistent hash ring.
+//
+// Each node is placed on the ring at replicas * weight points (virtual nodes)
+// and a key belongs to the first point clockwise from its hash.
+type Consistent struct {
+	mutex    *sync.RWMutex
+	replicas int
+	weights  map[string]int
+	points   []point // sorted by hash
+}
+
+var _ Ring = (*Consistent)(nil)
+
+// Creates a new ring with replicas virtual nodes per unit of weight.
+// If replicas <= 0, DefaultReplicas is used.
+func NewConsistent(replicas int) *Consistent {
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+
+	return &Consistent{
+		mutex:    &sync.RWMutex{},
+		replicas: replicas,
+		weights:  make(map[string]int),
+	}
+}
+
+// Add a node with a weight of 1.
+func (c *Consistent) Add(node string) {
+	c.AddWeighted(node, 1)
+}
+
+// Add a node receiving a share of keys proportional to weight.
+// Adding an existing node updates its weight. A weight <= 0 removes the node.
+func (c *Consistent) AddWeighted(node string, weight int) {
+	if weight <= 0 {
+		c.Remove(node)
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if w, ok := c.weights[node]; ok {
+		if w == weight {
+			return
+		}
+		c.removePoints(node)
+	}
+
+	c.weights[node] = weight
+	for i := 0; i < c.replicas*weight; i++ {
+		c.points = append(c.points, point{hash(node + "#" + strconv.Itoa(i)), node})
+	}
+
+	sort.Slice(c.points, func(i, j int) bool {
+		return c.points[i].hash < c.points[j].hash
+	})
+}
+
+// Remove a node and all of its virtual nodes.
+func (c *Consistent) Remove(node string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.weights[node]; !ok {
+		return
+	}
+
+	delete(c.weights, node)
+	c.removePoints(node)
+}
+
+// removes the virtual nodes of node keeping the points sorted.
+func (c *Consistent) removePoints(node string) {
+	points := c.points[:0]
+	for _, p := range c.points {
+		if p.node != node {
+			points = append(points, p)
+		}
+	}
+	c.points = points
+}
+
+// returns the index of the first point at or after h, wrapping around.
+func (c *Consistent) search(h uint64) int {
+	i := sort.Search(len(c.points), func(i int) bool {
+		return c.points[i].hash >= h
+	})
+
+	if i == len(c.points) {
+		return 0
+	}
+	return i
+}
+
+// Returns the node responsible for key.
+//
+// O(log v) where v is the number of virtual nodes.
+func (c *Consistent) Get(key string) (string, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if len(c.points) == 0 {
+		return "", false
+	}
+	return c.points[c.search(hash(key))].node, true
+}
+
+// Returns up to n distinct nodes found walking clockwise from key.
+func (c *Consistent) GetN(key string, n int) []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if n > len(c.weights) {
+		n = len(c.weights)
+	}
+
+	if n <= 0 {
+		return []string{}
+	}
+
+	nodes := make([]string, 0, n)
+	seen := make(map[string]struct{}, n)
+
+	for i := c.search(hash(key)); len(nodes) < n; i = (i + 1) % len(c.points) {
+		node := c.points[i].node
+		if _, ok := seen[node]; !ok {
+			seen[node] = struct{}{}
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// Returns the nodes in the ring in no particular order.
+func (c *Consistent) Nodes() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	nodes := make([]string, 0, len(c.weights))
+	for node := range c.weights {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
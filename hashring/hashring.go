@@ -0,0 +1,51 @@
+// Package hashring distributes keys over a changing set of nodes so that
+// only a small fraction of keys move when nodes are added or removed.
+//
+// Three strategies are provided:
+//   - Consistent: a hash ring with virtual nodes and weights.
+//   - Jump: Google's jump consistent hash, fast with no memory overhead,
+//     but nodes can only be removed cheaply from the end.
+//   - Rendezvous: highest random weight hashing with weights.
+//
+// All types are safe for concurrent use.
+package hashring
+
+import "hash/fnv"
+
+// Ring is the interface shared by all strategies.
+type Ring interface {
+	// Add a node. Adding an existing node is a no-op.
+	Add(node string)
+
+	// Remove a node. Removing a missing node is a no-op.
+	Remove(node string)
+
+	// Returns the node responsible for key, false if there are no nodes.
+	Get(key string) (string, bool)
+
+	// Returns up to n distinct nodes for key in order of preference,
+	// e.g. for placing replicas.
+	GetN(key string, n int) []string
+
+	// Returns the nodes in the ring.
+	Nodes() []string
+}
+
+// hash returns a well mixed 64-bit hash of s.
+// It is deterministic across processes so placements are stable.
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return mix(h.Sum64())
+}
+
+// mix is the splitmix64 finalizer. It spreads the bits of FNV,
+// which are poorly distributed for similar short strings.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,153 @@
+package hashring_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/abiiranathan/algo/hashring"
+)
+
+// constructors for every strategy
+var rings = map[string]func() hashring.Ring{
+	"Consistent": func() hashring.Ring { return hashring.NewConsistent(0) },
+	"Jump":       func() hashring.Ring { return hashring.NewJump() },
+	"Rendezvous": func() hashring.Ring { return hashring.NewRendezvous() },
+}
+
+// assigns each of n keys to a node
+func assign(r hashring.Ring, n int) map[string]string {
+	owners := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		key := "key-" + strconv.Itoa(i)
+		owners[key], _ = r.Get(key)
+	}
+	return owners
+}
+
+func TestRingBasics(t *testing.T) {
+	t.Parallel()
+
+	for name, newRing := range rings {
+		r := newRing()
+
+		if _, ok := r.Get("key"); ok {
+			t.Errorf("%s: Get on an empty ring should return false", name)
+		}
+
+		if len(r.GetN("key", 3)) != 0 {
+			t.Errorf("%s: GetN on an empty ring should be empty", name)
+		}
+
+		for i := 0; i < 5; i++ {
+			r.Add("worker-" + strconv.Itoa(i))
+		}
+		r.Add("worker-0")
+
+		if len(r.Nodes()) != 5 {
+			t.Errorf("%s: expected 5 nodes, got %d", name, len(r.Nodes()))
+		}
+
+		first, _ := r.Get("session-42")
+		again, _ := r.Get("session-42")
+		if first != again {
+			t.Errorf("%s: Get should be deterministic", name)
+		}
+
+		replicas := r.GetN("session-42", 3)
+		if len(replicas) != 3 || replicas[0] != first {
+			t.Errorf("%s: GetN should start with the primary node, got %v", name, replicas)
+		}
+
+		seen := map[string]bool{}
+		for _, node := range replicas {
+			if seen[node] {
+				t.Errorf("%s: GetN returned duplicate node %s", name, node)
+			}
+			seen[node] = true
+		}
+
+		if len(r.GetN("session-42", 10)) != 5 {
+			t.Errorf("%s: GetN should be capped at the number of nodes", name)
+		}
+	}
+}
+
+func TestRingMinimalRemapping(t *testing.T) {
+	t.Parallel()
+
+	for name, newRing := range rings {
+		r := newRing()
+		for i := 0; i < 10; i++ {
+			r.Add("worker-" + strconv.Itoa(i))
+		}
+
+		before := assign(r, 10000)
+
+		// removing the last node is cheap for every strategy
+		r.Remove("worker-9")
+		after := assign(r, 10000)
+
+		for key, owner := range before {
+			if owner != "worker-9" && after[key] != owner {
+				t.Fatalf("%s: key %s moved from %s to %s", name, key, owner, after[key])
+			}
+		}
+
+		// keys should be spread roughly evenly
+		counts := map[string]int{}
+		for _, owner := range after {
+			counts[owner]++
+		}
+
+		for node, c := range counts {
+			if c < 10000/9/2 || c > 10000/9*2 {
+				t.Errorf("%s: node %s owns %d keys, expected about %d", name, node, c, 10000/9)
+			}
+		}
+	}
+}
+
+func TestRingWeights(t *testing.T) {
+	t.Parallel()
+
+	c := hashring.NewConsistent(100)
+	c.AddWeighted("big", 3)
+	c.Add("small")
+
+	r := hashring.NewRendezvous("small")
+	r.AddWeighted("big", 3)
+
+	for name, ring := range map[string]hashring.Ring{"Consistent": c, "Rendezvous": r} {
+		counts := map[string]int{}
+		for _, owner := range assign(ring, 10000) {
+			counts[owner]++
+		}
+
+		ratio := float64(counts["big"]) / float64(counts["small"])
+		if ratio < 2 || ratio > 4 {
+			t.Errorf("%s: expected a 3:1 split, got %v", name, counts)
+		}
+	}
+
+	c.AddWeighted("big", 0)
+	if len(c.Nodes()) != 1 {
+		t.Error("a weight of zero should remove the node")
+	}
+}
+
+func TestJumpHash(t *testing.T) {
+	t.Parallel()
+
+	if hashring.JumpHash(1, 0) != -1 {
+		t.Error("JumpHash with no buckets should return -1")
+	}
+
+	// growing the bucket count only moves keys to the new bucket
+	for key := uint64(0); key < 1000; key++ {
+		a := hashring.JumpHash(key, 10)
+		b := hashring.JumpHash(key, 11)
+		if a != b && b != 10 {
+			t.Fatalf("key %d moved from %d to %d", key, a, b)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package hashring
+
+import "sync"
+
+// JumpHash maps key onto one of buckets buckets using the jump consistent
+// hash of Lamping and Veach. When the number of buckets grows from n to n+1
+// only 1/(n+1) of the keys move, all of them to the new bucket.
+//
+// Returns -1 if buckets <= 0.
+func JumpHash(key uint64, buckets int) int {
+	if buckets <= 0 {
+		return -1
+	}
+
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// Jump distributes keys over nodes with JumpHash.
+//
+// It needs no memory beyond the node list and is very fast, but keys are
+// only minimally remapped when nodes are added or removed at the end.
+// Removing a node from the middle shifts the buckets of all nodes after it.
+type Jump struct {
+	mutex *sync.RWMutex
+	nodes []string
+}
+
+var _ Ring = (*Jump)(nil)
+
+// Creates a new Jump with the initial nodes in order.
+func NewJump(nodes ...string) *Jump {
+	j := &Jump{mutex: &sync.RWMutex{}}
+	for _, node := range nodes {
+		j.Add(node)
+	}
+	return j
+}
+
+// returns the index of node or -1.
+func (j *Jump) index(node string) int {
+	for i, n := range j.nodes {
+		if n == node {
+			return i
+		}
+	}
+	return -1
+}
+
+// Append a node as the last bucket.
+func (j *Jump) Add(node string) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.index(node) < 0 {
+		j.nodes = append(j.nodes, node)
+	}
+}
+
+// Remove a node. See the type documentation for the cost of
+// removing a node other than the last.
+func (j *Jump) Remove(node string) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if i := j.index(node); i >= 0 {
+		j.nodes = append(j.nodes[:i], j.nodes[i+1:]...)
+	}
+}
+
+// Returns the node responsible for key.
+//
+// O(log n) where n is the number of nodes.
+func (j *Jump) Get(key string) (string, bool) {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+
+	if len(j.nodes) == 0 {
+		return "", false
+	}
+	return j.nodes[JumpHash(hash(key), len(j.nodes))], true
+}
+
+// Returns up to n distinct nodes: the bucket of key followed by
+// the buckets after it, wrapping around.
+func (j *Jump) GetN(key string, n int) []string {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+
+	if n > len(j.nodes) {
+		n = len(j.nodes)
+	}
+
+	if n <= 0 {
+		return []string{}
+	}
+
+	nodes := make([]string, n)
+	b := JumpHash(hash(key), len(j.nodes))
+	for i := range nodes {
+		nodes[i] = j.nodes[(b+i)%len(j.nodes)]
+	}
+	return nodes
+}
+
+// Returns the nodes in bucket order.
+func (j *Jump) Nodes() []string {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+
+	nodes := make([]string, len(j.nodes))
+	copy(nodes, j.nodes)
+	return nodes
+}
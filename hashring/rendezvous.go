@@ -0,0 +1,133 @@
+package hashring
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// Rendezvous distributes keys with highest random weight (HRW) hashing.
+//
+// Every node scores each key and the highest score wins, so removing
+// a node only moves the keys it owned. Lookups are O(n) in the
+// number of nodes, which suits small pools.
+type Rendezvous struct {
+	mutex   *sync.RWMutex
+	weights map[string]float64
+}
+
+var _ Ring = (*Rendezvous)(nil)
+
+// Creates a new Rendezvous with the initial nodes at a weight of 1.
+func NewRendezvous(nodes ...string) *Rendezvous {
+	r := &Rendezvous{
+		mutex:   &sync.RWMutex{},
+		weights: make(map[string]float64),
+	}
+
+	for _, node := range nodes {
+		r.Add(node)
+	}
+	return r
+}
+
+// Add a node with a weight of 1.
+func (r *Rendezvous) Add(node string) {
+	r.AddWeighted(node, 1)
+}
+
+// Add a node receiving a share of keys proportional to weight.
+// Adding an existing node updates its weight. A weight <= 0 removes the node.
+func (r *Rendezvous) AddWeighted(node string, weight float64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if weight <= 0 {
+		delete(r.weights, node)
+		return
+	}
+	r.weights[node] = weight
+}
+
+// Remove a node.
+func (r *Rendezvous) Remove(node string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.weights, node)
+}
+
+// score returns the weighted score of node for key.
+// Uses the logarithmic method so that weights are honoured exactly.
+func score(key, node string, weight float64) float64 {
+	h := hash(key + "\x00" + node)
+
+	// uniform in (0, 1)
+	u := (float64(h>>11) + 0.5) / (1 << 53)
+	return -weight / math.Log(u)
+}
+
+// Returns the node with the highest score for key.
+func (r *Rendezvous) Get(key string) (string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	best, bestScore := "", math.Inf(-1)
+	for node, w := range r.weights {
+		s := score(key, node, w)
+		// break ties by name so the result does not depend on map order
+		if s > bestScore || (s == bestScore && node < best) {
+			best, bestScore = node, s
+		}
+	}
+	return best, len(r.weights) > 0
+}
+
+// Returns up to n nodes for key ordered by descending score.
+func (r *Rendezvous) GetN(key string, n int) []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	type scored struct {
+		node  string
+		score float64
+	}
+
+	all := make([]scored, 0, len(r.weights))
+	for node, w := range r.weights {
+		all = append(all, scored{node, score(key, node, w)})
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].score != all[j].score {
+			return all[i].score > all[j].score
+		}
+		return all[i].node < all[j].node
+	})
+
+	if n > len(all) {
+		n = len(all)
+	}
+
+	if n <= 0 {
+		return []string{}
+	}
+
+	nodes := make([]string, n)
+	for i := range nodes {
+		nodes[i] = all[i].node
+	}
+	return nodes
+}
+
+// Returns the nodes in no particular order.
+func (r *Rendezvous) Nodes() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	nodes := make([]string, 0, len(r.weights))
+	for node := range r.weights {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
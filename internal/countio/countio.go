@@ -0,0 +1,61 @@
+// Package countio provides a reader and a writer that count the bytes
+// passing through them, for implementing io.WriterTo and io.ReaderFrom.
+package countio
+
+import (
+	"bufio"
+	"io"
+)
+
+// Writer counts the bytes written to the underlying writer.
+type Writer struct {
+	w io.Writer
+	n int64
+}
+
+// Creates a Writer writing to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (c *Writer) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Returns the number of bytes written so far.
+func (c *Writer) Count() int64 {
+	return c.n
+}
+
+// Reader counts the bytes read from a buffered reader.
+// It implements io.ByteReader so it can be used with encoding/binary.
+type Reader struct {
+	r *bufio.Reader
+	n int64
+}
+
+// Creates a Reader reading from r through a bufio.Reader.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+func (c *Reader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *Reader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+	return b, err
+}
+
+// Returns the number of bytes read so far.
+func (c *Reader) Count() int64 {
+	return c.n
+}
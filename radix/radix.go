@@ -0,0 +1,198 @@
+// Compressed radix (Patricia) tree implementation.
+//
+// Chains of nodes with a single child are merged into one edge labelled with
+// the whole substring, so long keys with few shared prefixes use far fewer
+// nodes than trie.Trie or basic_trie. Keys are compared byte by byte, which
+// works for any UTF-8 (or binary) string.
+package radix
+
+import (
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+// node is reached from its parent over the edge label.
+// children are kept sorted by the first byte of their labels.
+type node struct {
+	label    string
+	children []*node
+	terminal bool
+}
+
+// Tree is a compressed radix tree.
+type Tree struct {
+	root *node
+	size int
+}
+
+// creates a new empty radix tree.
+func New() *Tree {
+	return &Tree{root: &node{}}
+}
+
+// returns the index of the child whose label starts with c
+// and whether such a child exists.
+func (n *node) child(c byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label[0] >= c
+	})
+	return i, i < len(n.children) && n.children[i].label[0] == c
+}
+
+// returns the length of the common prefix of a and b.
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// Insert one or more words into the tree.
+//
+// Insertion is O(m) where m is the length of the word.
+func (t *Tree) Insert(words ...string) {
+	for _, word := range words {
+		t.insert(word)
+	}
+}
+
+func (t *Tree) insert(key string) {
+	n := t.root
+
+	for {
+		if key == "" {
+			if !n.terminal {
+				n.terminal = true
+				t.size++
+			}
+			return
+		}
+
+		i, ok := n.child(key[0])
+		if !ok {
+			// copy the label so the tree does not retain the caller's string
+			leaf := &node{label: strings.Clone(key), terminal: true}
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = leaf
+			t.size++
+			return
+		}
+
+		child := n.children[i]
+		l := commonPrefix(key, child.label)
+		if l < len(child.label) {
+			// split the edge at the end of the common prefix
+			mid := &node{label: child.label[:l], children: []*node{child}}
+			child.label = child.label[l:]
+			n.children[i] = mid
+			child = mid
+		}
+
+		key = key[l:]
+		n = child
+	}
+}
+
+// returns the node at the end of prefix, and the full key up to that node,
+// which may extend past prefix when prefix ends inside an edge.
+func (t *Tree) find(prefix string) (*node, string) {
+	n := t.root
+	key := ""
+
+	for prefix != "" {
+		i, ok := n.child(prefix[0])
+		if !ok {
+			return nil, ""
+		}
+
+		child := n.children[i]
+		l := commonPrefix(prefix, child.label)
+		if l < len(prefix) && l < len(child.label) {
+			return nil, ""
+		}
+
+		key += child.label
+		prefix = prefix[l:]
+		n = child
+	}
+	return n, key
+}
+
+// Returns true if word exists in the tree.
+//
+// O(m) time complexity
+func (t *Tree) Exists(word string) bool {
+	n, key := t.find(word)
+	return n != nil && n.terminal && len(key) == len(word)
+}
+
+// appends every word below n, in lexicographic order, to words.
+func suggestionsRec(n *node, prefix string, words *[]string) {
+	if n.terminal {
+		*words = append(*words, prefix)
+	}
+
+	for _, child := range n.children {
+		suggestionsRec(child, prefix+child.label, words)
+	}
+}
+
+// Returns the words starting with query in lexicographic order.
+func (t *Tree) Suggestions(query string) []string {
+	words := []string{}
+
+	n, key := t.find(query)
+	if n == nil {
+		return words
+	}
+
+	suggestionsRec(n, key, &words)
+	return words
+}
+
+// Returns all words in the tree in lexicographic order.
+func (t *Tree) Words() []string {
+	return t.Suggestions("")
+}
+
+// Returns the number of words in the tree.
+func (t *Tree) Size() int {
+	return t.size
+}
+
+// counts the nodes below n.
+func countNodesRec(n *node, count *int) {
+	for _, child := range n.children {
+		(*count)++
+		countNodesRec(child, count)
+	}
+}
+
+// Returns the number of nodes in the tree, excluding the root.
+func (t *Tree) Nodes() int {
+	count := 0
+	countNodesRec(t.root, &count)
+	return count
+}
+
+// adds the bytes used by n and its descendants to total.
+func memoryRec(n *node, total *int) {
+	*total += int(unsafe.Sizeof(*n)) + len(n.label) + cap(n.children)*int(unsafe.Sizeof(n))
+	for _, child := range n.children {
+		memoryRec(child, total)
+	}
+}
+
+// Returns an estimate of the memory used by the tree in bytes,
+// counting nodes, labels and child slices.
+//
+// Labels created by splitting an edge share the bytes of the
+// original label, so this slightly overestimates.
+func (t *Tree) Memory() int {
+	total := 0
+	memoryRec(t.root, &total)
+	return total
+}
@@ -0,0 +1,84 @@
+package radix_test
+
+import (
+	"testing"
+
+	"github.com/abiiranathan/algo/radix"
+)
+
+func TestRadix(t *testing.T) {
+	tr := radix.New()
+
+	if tr.Size() != 0 || tr.Nodes() != 0 {
+		t.Errorf("empty tree should have no words or nodes")
+	}
+
+	tr.Insert("romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus")
+	tr.Insert("romane")
+
+	if tr.Size() != 7 {
+		t.Errorf("expected 7 words, got %d", tr.Size())
+	}
+
+	// r, om, an, e, us, ulus, ub, e, ns, r, ic, on, undus
+	if tr.Nodes() != 13 {
+		t.Errorf("expected 13 nodes, got %d", tr.Nodes())
+	}
+
+	for _, w := range []string{"romane", "rubicon", "ruber"} {
+		if !tr.Exists(w) {
+			t.Errorf("expected %q to exist", w)
+		}
+	}
+
+	for _, w := range []string{"rom", "rub", "rubico", "rubiconx", "x", ""} {
+		if tr.Exists(w) {
+			t.Errorf("expected %q not to exist", w)
+		}
+	}
+
+	got := tr.Suggestions("rubi")
+	if len(got) != 2 || got[0] != "rubicon" || got[1] != "rubicundus" {
+		t.Errorf("unexpected suggestions for rubi: %v", got)
+	}
+
+	// prefix ending inside an edge
+	got = tr.Suggestions("rom")
+	if len(got) != 3 || got[0] != "romane" {
+		t.Errorf("unexpected suggestions for rom: %v", got)
+	}
+
+	if len(tr.Suggestions("rox")) != 0 || len(tr.Suggestions("romanex")) != 0 {
+		t.Errorf("expected no suggestions")
+	}
+
+	words := tr.Words()
+	for i := 1; i < len(words); i++ {
+		if words[i-1] >= words[i] {
+			t.Errorf("Words should be sorted, got %v", words)
+		}
+	}
+
+	// a prefix of an existing edge becomes a word
+	tr.Insert("rom", "")
+	if !tr.Exists("rom") || !tr.Exists("") || tr.Size() != 9 {
+		t.Errorf("inserting a prefix of an edge failed")
+	}
+
+	if tr.Memory() <= 0 {
+		t.Errorf("Memory should be positive")
+	}
+}
+
+func TestRadixUnicode(t *testing.T) {
+	tr := radix.New()
+	tr.Insert("東京", "東京都", "東北")
+
+	if !tr.Exists("東京") || tr.Exists("東") {
+		t.Errorf("unexpected membership")
+	}
+
+	if got := tr.Suggestions("東"); len(got) != 3 {
+		t.Errorf("expected 3 suggestions, got %v", got)
+	}
+}
@@ -0,0 +1,151 @@
+package set
+
+// DisjointSet (union-find) tracks a partition of elements into disjoint sets.
+// It uses path compression and union by rank so that Find and Union
+// run in near-constant amortized time.
+type DisjointSet[T comparable] struct {
+	index  map[T]int // element to position in the slices below
+	elems  []T
+	parent []int
+	rank   []int
+	size   []int
+	count  int // number of disjoint sets
+}
+
+// Create a new disjoint set where each initial element is in a set of its own.
+func NewDisjointSet[T comparable](initial ...T) *DisjointSet[T] {
+	d := &DisjointSet[T]{index: make(map[T]int)}
+
+	for _, v := range initial {
+		d.Add(v)
+	}
+	return d
+}
+
+// Add element as a singleton set.
+// Returns false if the element is already present.
+func (d *DisjointSet[T]) Add(element T) bool {
+	if _, exists := d.index[element]; exists {
+		return false
+	}
+
+	i := len(d.elems)
+	d.index[element] = i
+	d.elems = append(d.elems, element)
+	d.parent = append(d.parent, i)
+	d.rank = append(d.rank, 0)
+	d.size = append(d.size, 1)
+	d.count++
+	return true
+}
+
+// Test to see whether or not the element is in the disjoint set
+func (d *DisjointSet[T]) Has(element T) bool {
+	_, exists := d.index[element]
+	return exists
+}
+
+// find returns the root index of i, compressing the path along the way.
+func (d *DisjointSet[T]) find(i int) int {
+	root := i
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+
+	for d.parent[i] != root {
+		next := d.parent[i]
+		d.parent[i] = root
+		i = next
+	}
+	return root
+}
+
+// Returns the representative element of the set containing element.
+// If element is not present, ok is false.
+func (d *DisjointSet[T]) Find(element T) (root T, ok bool) {
+	i, exists := d.index[element]
+	if !exists {
+		return root, false
+	}
+	return d.elems[d.find(i)], true
+}
+
+// Merge the sets containing a and b. Elements that are not yet present
+// are added first. Returns false if a and b were already in the same set.
+func (d *DisjointSet[T]) Union(a, b T) bool {
+	d.Add(a)
+	d.Add(b)
+
+	ra, rb := d.find(d.index[a]), d.find(d.index[b])
+	if ra == rb {
+		return false
+	}
+
+	// attach the shorter tree under the taller one
+	if d.rank[ra] < d.rank[rb] {
+		ra, rb = rb, ra
+	}
+
+	d.parent[rb] = ra
+	d.size[ra] += d.size[rb]
+	if d.rank[ra] == d.rank[rb] {
+		d.rank[ra]++
+	}
+	d.count--
+	return true
+}
+
+// Returns true if a and b are present and belong to the same set.
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	i, ok := d.index[a]
+	if !ok {
+		return false
+	}
+
+	j, ok := d.index[b]
+	if !ok {
+		return false
+	}
+	return d.find(i) == d.find(j)
+}
+
+// Returns the number of elements in the set containing element
+// or 0 if element is not present.
+func (d *DisjointSet[T]) SetSize(element T) int {
+	i, exists := d.index[element]
+	if !exists {
+		return 0
+	}
+	return d.size[d.find(i)]
+}
+
+// Return the number of elements in the disjoint set
+func (d *DisjointSet[T]) Len() int {
+	return len(d.elems)
+}
+
+// Return the number of disjoint sets (components)
+func (d *DisjointSet[T]) Count() int {
+	return d.count
+}
+
+// Returns the current components, each as a slice of its elements.
+// Components are ordered by their first added element and elements
+// within a component keep their insertion order.
+func (d *DisjointSet[T]) Sets() [][]T {
+	groups := make(map[int]int, d.count) // root to position in sets
+	sets := make([][]T, 0, d.count)
+
+	for i, elem := range d.elems {
+		root := d.find(i)
+
+		pos, exists := groups[root]
+		if !exists {
+			pos = len(sets)
+			groups[root] = pos
+			sets = append(sets, make([]T, 0, d.size[root]))
+		}
+		sets[pos] = append(sets[pos], elem)
+	}
+	return sets
+}
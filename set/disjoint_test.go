@@ -0,0 +1,63 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestDisjointSet(t *testing.T) {
+	t.Parallel()
+	d := NewDisjointSet("a", "b", "c", "d", "e")
+
+	if d.Count() != 5 || d.Len() != 5 {
+		t.Errorf("expected 5 singleton sets, got %d", d.Count())
+	}
+
+	if d.Add("a") {
+		t.Errorf("Add should return false for an existing element")
+	}
+
+	if !d.Union("a", "b") || !d.Union("c", "d") || !d.Union("b", "d") {
+		t.Errorf("Union of disjoint sets should return true")
+	}
+
+	if d.Union("a", "c") {
+		t.Errorf("Union of connected elements should return false")
+	}
+
+	if !d.Connected("a", "d") {
+		t.Errorf("a and d should be connected")
+	}
+
+	if d.Connected("a", "e") || d.Connected("a", "missing") {
+		t.Errorf("a should not be connected to e or a missing element")
+	}
+
+	if d.SetSize("c") != 4 || d.SetSize("e") != 1 || d.SetSize("missing") != 0 {
+		t.Errorf("invalid set sizes")
+	}
+
+	ra, _ := d.Find("a")
+	rd, _ := d.Find("d")
+	if ra != rd {
+		t.Errorf("a and d should share a representative")
+	}
+
+	if _, ok := d.Find("missing"); ok {
+		t.Errorf("Find on a missing element should return false")
+	}
+
+	// Union implicitly adds new elements
+	d.Union("f", "e")
+	if d.Len() != 6 || d.Count() != 2 {
+		t.Errorf("expected 6 elements in 2 sets, got %d in %d", d.Len(), d.Count())
+	}
+
+	sets := d.Sets()
+	if len(sets) != 2 {
+		t.Fatalf("expected 2 components, got %d", len(sets))
+	}
+
+	if len(sets[0]) != 4 || sets[0][0] != "a" || len(sets[1]) != 2 || sets[1][0] != "e" {
+		t.Errorf("unexpected components: %v", sets)
+	}
+}
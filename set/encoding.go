@@ -0,0 +1,71 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// returns the elements of the set as a slice
+func (s *Set[T]) elements() []T {
+	elems := make([]T, 0, len(s.hash))
+	for k := range s.hash {
+		elems = append(elems, k)
+	}
+	return elems
+}
+
+// replaces the contents of the set with elems
+func (s *Set[T]) replace(elems []T) {
+	s.hash = make(map[T]struct{}, len(elems))
+	for _, v := range elems {
+		s.hash[v] = struct{}{}
+	}
+}
+
+// MarshalJSON encodes the set as a JSON array in no particular order.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.elements())
+}
+
+// UnmarshalJSON replaces the contents of the set with the decoded JSON array.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var elems []T
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+
+	s.replace(elems)
+	return nil
+}
+
+// MarshalBinary encodes the set using encoding/gob.
+func (s *Set[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.elements()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the contents of the set with data
+// produced by MarshalBinary.
+func (s *Set[T]) UnmarshalBinary(data []byte) error {
+	var elems []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&elems); err != nil {
+		return err
+	}
+
+	s.replace(elems)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder. See MarshalBinary.
+func (s *Set[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder. See UnmarshalBinary.
+func (s *Set[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
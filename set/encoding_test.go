@@ -0,0 +1,64 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+)
+
+func TestSetJSON(t *testing.T) {
+	t.Parallel()
+	s := New("a")
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `["a"]` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	var decoded Set[int]
+	if err := json.Unmarshal([]byte(`[1, 2, 2, 3]`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Len() != 3 || !decoded.Has(2) {
+		t.Error("UnmarshalJSON should decode into a zero Set")
+	}
+
+	if err := json.Unmarshal([]byte(`{}`), &decoded); err == nil {
+		t.Error("UnmarshalJSON should fail on invalid input")
+	}
+}
+
+func TestSetGob(t *testing.T) {
+	t.Parallel()
+	s := New(1, 2, 3)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := New[int]()
+	if err := gob.NewDecoder(&buf).Decode(decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Len() != 3 || !decoded.SubsetOf(s) {
+		t.Error("gob round trip failed")
+	}
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var zero Set[int]
+	if err := zero.UnmarshalBinary(data); err != nil || zero.Len() != 3 {
+		t.Error("binary round trip failed")
+	}
+}
@@ -0,0 +1,169 @@
+package set
+
+import "sort"
+
+// Generic MultiSet (bag) implementation.
+// Unlike Set, every element carries a multiplicity (count).
+type MultiSet[T comparable] struct {
+	counts map[T]int
+}
+
+// Element pairs a value in a MultiSet with its multiplicity.
+type Element[T comparable] struct {
+	Value T
+	Count int
+}
+
+// Create a new multiset. Each initial element is added once,
+// so repeated values increase the count.
+func NewMultiSet[T comparable](initial ...T) *MultiSet[T] {
+	m := &MultiSet[T]{counts: make(map[T]int)}
+
+	for _, v := range initial {
+		m.Add(v, 1)
+	}
+	return m
+}
+
+// Add n occurrences of element to the multiset.
+// Non-positive values of n are ignored.
+func (m *MultiSet[T]) Add(element T, n int) {
+	if n <= 0 {
+		return
+	}
+	m.counts[element] += n
+}
+
+// Remove up to n occurrences of element from the multiset.
+// The element is dropped entirely once its count reaches zero.
+// Non-positive values of n are ignored.
+func (m *MultiSet[T]) Remove(element T, n int) {
+	if n <= 0 {
+		return
+	}
+
+	c, exists := m.counts[element]
+	if !exists {
+		return
+	}
+
+	if c <= n {
+		delete(m.counts, element)
+	} else {
+		m.counts[element] = c - n
+	}
+}
+
+// Returns the number of occurrences of element in the multiset.
+func (m *MultiSet[T]) Count(element T) int {
+	return m.counts[element]
+}
+
+// Test to see whether or not the element is in the multiset
+func (m *MultiSet[T]) Has(element T) bool {
+	_, exists := m.counts[element]
+	return exists
+}
+
+// Return the total number of items in the multiset, counting repeats.
+func (m *MultiSet[T]) Len() int {
+	n := 0
+	for _, c := range m.counts {
+		n += c
+	}
+	return n
+}
+
+// Return the number of distinct items in the multiset.
+func (m *MultiSet[T]) Distinct() int {
+	return len(m.counts)
+}
+
+// Call f for each distinct item in the multiset along with its count.
+func (m *MultiSet[T]) ForEach(f func(elem T, count int)) {
+	for k, c := range m.counts {
+		f(k, c)
+	}
+}
+
+// Returns the k most common elements ordered from the highest count to the lowest.
+// Elements with equal counts are returned in no particular order.
+// If k is negative or larger than the number of distinct elements,
+// all elements are returned.
+func (m *MultiSet[T]) MostCommon(k int) []Element[T] {
+	elems := make([]Element[T], 0, len(m.counts))
+	for v, c := range m.counts {
+		elems = append(elems, Element[T]{Value: v, Count: c})
+	}
+
+	sort.Slice(elems, func(i, j int) bool {
+		return elems[i].Count > elems[j].Count
+	})
+
+	if k >= 0 && k < len(elems) {
+		elems = elems[:k]
+	}
+	return elems
+}
+
+// Find the union of two multisets.
+// The count of each element is the maximum of its counts in both multisets.
+func (m *MultiSet[T]) Union(other *MultiSet[T]) *MultiSet[T] {
+	u := make(map[T]int, len(m.counts))
+
+	for k, c := range m.counts {
+		u[k] = c
+	}
+
+	for k, c := range other.counts {
+		if c > u[k] {
+			u[k] = c
+		}
+	}
+	return &MultiSet[T]{u}
+}
+
+// Find the sum of two multisets.
+// The count of each element is the sum of its counts in both multisets.
+func (m *MultiSet[T]) Sum(other *MultiSet[T]) *MultiSet[T] {
+	s := make(map[T]int, len(m.counts))
+
+	for k, c := range m.counts {
+		s[k] = c
+	}
+
+	for k, c := range other.counts {
+		s[k] += c
+	}
+	return &MultiSet[T]{s}
+}
+
+// Find the intersection of two multisets.
+// The count of each element is the minimum of its counts in both multisets.
+func (m *MultiSet[T]) Intersection(other *MultiSet[T]) *MultiSet[T] {
+	n := make(map[T]int)
+
+	for k, c := range m.counts {
+		if oc, exists := other.counts[k]; exists {
+			if oc < c {
+				c = oc
+			}
+			n[k] = c
+		}
+	}
+	return &MultiSet[T]{n}
+}
+
+// Find the difference between two multisets.
+// The count of each element is its count in m less its count in other.
+// Elements whose count drops to zero or below are omitted.
+func (m *MultiSet[T]) Difference(other *MultiSet[T]) *MultiSet[T] {
+	n := make(map[T]int)
+
+	for k, c := range m.counts {
+		if d := c - other.counts[k]; d > 0 {
+			n[k] = d
+		}
+	}
+	return &MultiSet[T]{n}
+}
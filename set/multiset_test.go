@@ -0,0 +1,78 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestMultiSet(t *testing.T) {
+	t.Parallel()
+	m := NewMultiSet("go", "go", "rust")
+
+	if m.Count("go") != 2 {
+		t.Errorf("Count of go should be 2, got %d", m.Count("go"))
+	}
+
+	m.Add("go", 3)
+	m.Add("zig", 0)
+
+	if m.Count("go") != 5 {
+		t.Errorf("Count of go should be 5, got %d", m.Count("go"))
+	}
+
+	if m.Has("zig") {
+		t.Errorf("Adding zero occurrences should not insert the element")
+	}
+
+	if m.Len() != 6 || m.Distinct() != 2 {
+		t.Errorf("expected Len 6 and Distinct 2, got %d and %d", m.Len(), m.Distinct())
+	}
+
+	m.Remove("go", 4)
+	if m.Count("go") != 1 {
+		t.Errorf("Count of go should be 1 after removal, got %d", m.Count("go"))
+	}
+
+	m.Remove("rust", 10)
+	if m.Has("rust") {
+		t.Errorf("Removing more than the count should delete the element")
+	}
+
+	// MostCommon
+	tags := NewMultiSet[string]()
+	tags.Add("a", 5)
+	tags.Add("b", 3)
+	tags.Add("c", 1)
+
+	top := tags.MostCommon(2)
+	if len(top) != 2 || top[0].Value != "a" || top[1].Value != "b" {
+		t.Errorf("MostCommon(2) should return a and b, got %v", top)
+	}
+
+	if len(tags.MostCommon(-1)) != 3 {
+		t.Errorf("MostCommon(-1) should return all elements")
+	}
+
+	// Algebra
+	m1 := NewMultiSet(1, 1, 1, 2, 3)
+	m2 := NewMultiSet(1, 2, 2, 4)
+
+	u := m1.Union(m2)
+	if u.Count(1) != 3 || u.Count(2) != 2 || u.Count(3) != 1 || u.Count(4) != 1 {
+		t.Errorf("Union should take the maximum count")
+	}
+
+	s := m1.Sum(m2)
+	if s.Count(1) != 4 || s.Count(2) != 3 || s.Len() != 9 {
+		t.Errorf("Sum should add counts")
+	}
+
+	i := m1.Intersection(m2)
+	if i.Count(1) != 1 || i.Count(2) != 1 || i.Has(3) || i.Has(4) {
+		t.Errorf("Intersection should take the minimum count")
+	}
+
+	d := m1.Difference(m2)
+	if d.Count(1) != 2 || d.Has(2) || d.Count(3) != 1 || d.Has(4) {
+		t.Errorf("Difference should subtract counts")
+	}
+}
@@ -0,0 +1,81 @@
+package trie
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// FuzzyMatch is a word found by FuzzySuggestions with its
+// Levenshtein distance from the query.
+type FuzzyMatch struct {
+	Word     string
+	Distance int
+}
+
+// Returns the words whose Levenshtein (edit) distance from query is at most
+// maxEdits, ordered by distance and then alphabetically.
+//
+// The trie is walked once, keeping one row of the edit distance table per
+// node, and branches are abandoned as soon as every entry of the row exceeds
+// maxEdits. Shared prefixes therefore share work.
+func (t *Trie) FuzzySuggestions(query string, maxEdits int) []FuzzyMatch {
+	matches := []FuzzyMatch{}
+	if maxEdits < 0 || !utf8.ValidString(query) {
+		return matches
+	}
+
+	target := []rune(query)
+
+	// distance from the empty word to each prefix of query
+	row := make([]int, len(target)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	if t.isWordEnd && row[len(target)] <= maxEdits {
+		matches = append(matches, FuzzyMatch{"", row[len(target)]})
+	}
+
+	for _, e := range t.children {
+		fuzzyRec(e.node, e.char, string(e.char), target, row, maxEdits, &matches)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].Word < matches[j].Word
+	})
+	return matches
+}
+
+// Computes the edit distance row for node, reached by char from a node
+// whose row is prev, then descends while the row is within budget.
+func fuzzyRec(node *Trie, char rune, word string, target []rune, prev []int, maxEdits int, matches *[]FuzzyMatch) {
+	row := make([]int, len(prev))
+	row[0] = prev[0] + 1
+	best := row[0]
+
+	for i := 1; i < len(row); i++ {
+		cost := 1
+		if target[i-1] == char {
+			cost = 0
+		}
+
+		// insertion, deletion, substitution
+		row[i] = min(row[i-1]+1, prev[i]+1, prev[i-1]+cost)
+		best = min(best, row[i])
+	}
+
+	if d := row[len(row)-1]; node.isWordEnd && d <= maxEdits {
+		*matches = append(*matches, FuzzyMatch{word, d})
+	}
+
+	if best > maxEdits {
+		return
+	}
+
+	for _, e := range node.children {
+		fuzzyRec(e.node, e.char, word+string(e.char), target, row, maxEdits, matches)
+	}
+}
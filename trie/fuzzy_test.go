@@ -0,0 +1,44 @@
+package trie_test
+
+import (
+	"testing"
+
+	"github.com/abiiranathan/algo/trie"
+)
+
+func TestTrieFuzzySuggestions(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.Insert("paracetamol", "ibuprofen", "amoxicillin", "amoxil", "aspirin", "aspirine")
+
+	matches := tr.FuzzySuggestions("paracetmol", 1)
+	if len(matches) != 1 || matches[0].Word != "paracetamol" || matches[0].Distance != 1 {
+		t.Errorf("expected paracetamol at distance 1, got %v", matches)
+	}
+
+	matches = tr.FuzzySuggestions("aspirin", 1)
+	if len(matches) != 2 || matches[0].Word != "aspirin" || matches[0].Distance != 0 ||
+		matches[1].Word != "aspirine" || matches[1].Distance != 1 {
+		t.Errorf("expected exact match followed by aspirine, got %v", matches)
+	}
+
+	// substitution and transposition-like errors
+	matches = tr.FuzzySuggestions("ibuprfoen", 2)
+	if len(matches) != 1 || matches[0].Word != "ibuprofen" || matches[0].Distance != 2 {
+		t.Errorf("expected ibuprofen at distance 2, got %v", matches)
+	}
+
+	if len(tr.FuzzySuggestions("xyz", 1)) != 0 {
+		t.Errorf("expected no matches for xyz")
+	}
+
+	if len(tr.FuzzySuggestions("aspirin", -1)) != 0 {
+		t.Errorf("a negative budget should return no matches")
+	}
+
+	// runes, not bytes, are edited
+	tr.Insert("café")
+	matches = tr.FuzzySuggestions("cafe", 1)
+	if len(matches) != 1 || matches[0].Distance != 1 {
+		t.Errorf("expected café at distance 1, got %v", matches)
+	}
+}
@@ -0,0 +1,166 @@
+package trie
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// mapNode is a node of a Map. value is only meaningful if hasValue is true.
+type mapNode[V any] struct {
+	children map[rune]*mapNode[V]
+	value    V
+	hasValue bool
+}
+
+// Map is a trie that stores a value of type V for every key.
+// Like Trie, keys are traversed rune by rune.
+//
+// Useful for prefix lookups such as routing tables.
+type Map[V any] struct {
+	root *mapNode[V]
+	size int
+}
+
+// initializes a new Map
+func NewMap[V any]() *Map[V] {
+	return &Map[V]{root: &mapNode[V]{children: map[rune]*mapNode[V]{}}}
+}
+
+// Stores value under key, replacing any previous value.
+//
+// O(m) where m is the number of runes in key.
+func (m *Map[V]) Put(key string, value V) {
+	node := m.root
+	for _, x := range key {
+		child, ok := node.children[x]
+		if !ok {
+			child = &mapNode[V]{children: map[rune]*mapNode[V]{}}
+			node.children[x] = child
+		}
+		node = child
+	}
+
+	if !node.hasValue {
+		m.size++
+	}
+	node.value = value
+	node.hasValue = true
+}
+
+// returns the node for key or nil.
+func (m *Map[V]) find(key string) *mapNode[V] {
+	node := m.root
+	for _, x := range key {
+		child, ok := node.children[x]
+		if !ok {
+			return nil
+		}
+		node = child
+	}
+	return node
+}
+
+// Returns the value stored under key and true if present.
+func (m *Map[V]) Get(key string) (value V, ok bool) {
+	node := m.find(key)
+	if node == nil || !node.hasValue {
+		return value, false
+	}
+	return node.value, true
+}
+
+// Removes key and its value, pruning branches left without values.
+// Returns false if key was not present.
+func (m *Map[V]) Delete(key string) bool {
+	path := []*mapNode[V]{m.root}
+	runes := []rune{}
+
+	node := m.root
+	for _, x := range key {
+		child, ok := node.children[x]
+		if !ok {
+			return false
+		}
+		node = child
+		path = append(path, node)
+		runes = append(runes, x)
+	}
+
+	if !node.hasValue {
+		return false
+	}
+
+	var zero V
+	node.value = zero
+	node.hasValue = false
+	m.size--
+
+	for i := len(path) - 1; i > 0; i-- {
+		if path[i].hasValue || len(path[i].children) > 0 {
+			break
+		}
+		delete(path[i-1].children, runes[i-1])
+	}
+	return true
+}
+
+// Returns the number of keys in the map.
+func (m *Map[V]) Len() int {
+	return m.size
+}
+
+// Returns the longest stored key that is a prefix of s, with its value.
+// ok is false if no stored key prefixes s.
+//
+// O(m) where m is the number of runes in s.
+func (m *Map[V]) LongestPrefixOf(s string) (key string, value V, ok bool) {
+	node := m.root
+	if node.hasValue {
+		key, value, ok = "", node.value, true
+	}
+
+	for i, x := range s {
+		child, exists := node.children[x]
+		if !exists {
+			break
+		}
+		node = child
+
+		if node.hasValue {
+			// i is the byte offset of x, so include its encoding
+			_, size := utf8.DecodeRuneInString(s[i:])
+			key, value, ok = s[:i+size], node.value, true
+		}
+	}
+	return key, value, ok
+}
+
+// Calls fn for every key starting with prefix, in lexicographic order,
+// until fn returns false.
+func (m *Map[V]) WalkPrefix(prefix string, fn func(key string, value V) bool) {
+	node := m.find(prefix)
+	if node == nil {
+		return
+	}
+	walkMap(node, prefix, fn)
+}
+
+// visits node and its descendants in order. Returns false to stop the walk.
+func walkMap[V any](node *mapNode[V], key string, fn func(key string, value V) bool) bool {
+	if node.hasValue && !fn(key, node.value) {
+		return false
+	}
+
+	runes := make([]rune, 0, len(node.children))
+	for x := range node.children {
+		runes = append(runes, x)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	for _, x := range runes {
+		if !walkMap(node.children[x], key+string(x), fn) {
+			return false
+		}
+	}
+	return true
+}
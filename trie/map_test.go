@@ -0,0 +1,80 @@
+package trie_test
+
+import (
+	"testing"
+
+	"github.com/abiiranathan/algo/trie"
+)
+
+func TestMap(t *testing.T) {
+	routes := trie.NewMap[string]()
+	routes.Put("/", "index")
+	routes.Put("/api", "api")
+	routes.Put("/api/users", "users")
+	routes.Put("/api/users", "users-v2")
+	routes.Put("/static/", "static")
+
+	if routes.Len() != 4 {
+		t.Errorf("expected 4 keys, got %d", routes.Len())
+	}
+
+	if v, ok := routes.Get("/api/users"); !ok || v != "users-v2" {
+		t.Errorf("Put should replace the value, got %q", v)
+	}
+
+	if _, ok := routes.Get("/ap"); ok {
+		t.Errorf("Get should not return a value for an intermediate node")
+	}
+
+	key, v, ok := routes.LongestPrefixOf("/api/users/42")
+	if !ok || key != "/api/users" || v != "users-v2" {
+		t.Errorf("unexpected longest prefix %q => %q", key, v)
+	}
+
+	key, _, _ = routes.LongestPrefixOf("/about")
+	if key != "/" {
+		t.Errorf("expected / as the longest prefix, got %q", key)
+	}
+
+	if _, _, ok := routes.LongestPrefixOf("api"); ok {
+		t.Errorf("no key should prefix api")
+	}
+
+	keys := []string{}
+	routes.WalkPrefix("/api", func(key string, value string) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	if len(keys) != 2 || keys[0] != "/api" || keys[1] != "/api/users" {
+		t.Errorf("unexpected keys under /api: %v", keys)
+	}
+
+	n := 0
+	routes.WalkPrefix("", func(key string, value string) bool {
+		n++
+		return n < 2
+	})
+
+	if n != 2 {
+		t.Errorf("WalkPrefix should stop when fn returns false")
+	}
+
+	if !routes.Delete("/api") || routes.Delete("/api") || routes.Len() != 3 {
+		t.Errorf("Delete failed")
+	}
+
+	if v, _ := routes.Get("/api/users"); v != "users-v2" {
+		t.Errorf("Delete should not affect longer keys")
+	}
+}
+
+func TestMapUnicodePrefix(t *testing.T) {
+	m := trie.NewMap[int]()
+	m.Put("東京", 1)
+
+	key, v, ok := m.LongestPrefixOf("東京都")
+	if !ok || key != "東京" || v != 1 {
+		t.Errorf("unexpected longest prefix %q => %d", key, v)
+	}
+}
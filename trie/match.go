@@ -0,0 +1,218 @@
+package trie
+
+import (
+	"errors"
+	"sort"
+	"unicode/utf8"
+)
+
+// ErrBadPattern is returned by Match when the pattern is malformed.
+var ErrBadPattern = errors.New("trie: syntax error in pattern")
+
+// the kinds of pattern tokens
+const (
+	tokenLiteral = iota // a single rune
+	tokenAny            // ? matches any single rune
+	tokenStar           // * matches any run of runes, including none
+	tokenClass          // [...] matches one rune in a set
+)
+
+// runeRange is an inclusive range of runes in a character class.
+type runeRange struct {
+	lo, hi rune
+}
+
+// token is one element of a compiled pattern.
+type token struct {
+	kind    int
+	char    rune
+	ranges  []runeRange
+	negated bool
+}
+
+// matches reports whether the single-rune token tok accepts r.
+func (tok *token) matches(r rune) bool {
+	switch tok.kind {
+	case tokenLiteral:
+		return tok.char == r
+	case tokenAny:
+		return true
+	case tokenClass:
+		in := false
+		for _, rr := range tok.ranges {
+			if rr.lo <= r && r <= rr.hi {
+				in = true
+				break
+			}
+		}
+		return in != tok.negated
+	}
+	return false
+}
+
+// compilePattern splits pattern into tokens.
+//
+// Supported syntax, as in path.Match:
+//
+//	?       any single rune
+//	*       any run of runes, including the empty run
+//	[abc]   one of a, b or c
+//	[a-z]   one rune in the range a to z
+//	[^a-z]  one rune not in the class ([!a-z] is also accepted)
+//	\c      the rune c literally
+func compilePattern(pattern string) ([]token, error) {
+	if !utf8.ValidString(pattern) {
+		return nil, ErrBadPattern
+	}
+
+	runes := []rune(pattern)
+	tokens := []token{}
+
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '?':
+			tokens = append(tokens, token{kind: tokenAny})
+		case '*':
+			// consecutive stars are equivalent to one
+			if len(tokens) == 0 || tokens[len(tokens)-1].kind != tokenStar {
+				tokens = append(tokens, token{kind: tokenStar})
+			}
+		case '\\':
+			i++
+			if i == len(runes) {
+				return nil, ErrBadPattern
+			}
+			tokens = append(tokens, token{kind: tokenLiteral, char: runes[i]})
+		case '[':
+			tok, end, err := compileClass(runes, i+1)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i = end
+		default:
+			tokens = append(tokens, token{kind: tokenLiteral, char: r})
+		}
+	}
+	return tokens, nil
+}
+
+// compileClass parses a character class starting after its '['
+// and returns it with the index of the closing ']'.
+func compileClass(runes []rune, i int) (token, int, error) {
+	tok := token{kind: tokenClass}
+	if i < len(runes) && (runes[i] == '^' || runes[i] == '!') {
+		tok.negated = true
+		i++
+	}
+
+	// reads one possibly escaped rune of the class
+	next := func() (rune, error) {
+		if i < len(runes) && runes[i] == '\\' {
+			i++
+		}
+
+		if i >= len(runes) {
+			return 0, ErrBadPattern
+		}
+
+		r := runes[i]
+		i++
+		return r, nil
+	}
+
+	for {
+		if i >= len(runes) {
+			return tok, 0, ErrBadPattern
+		}
+
+		if runes[i] == ']' && len(tok.ranges) > 0 {
+			return tok, i, nil
+		}
+
+		lo, err := next()
+		if err != nil {
+			return tok, 0, err
+		}
+
+		hi := lo
+		if i+1 < len(runes) && runes[i] == '-' && runes[i+1] != ']' {
+			i++
+			if hi, err = next(); err != nil {
+				return tok, 0, err
+			}
+
+			if hi < lo {
+				return tok, 0, ErrBadPattern
+			}
+		}
+		tok.ranges = append(tok.ranges, runeRange{lo, hi})
+	}
+}
+
+// state is a position in both the trie and the pattern.
+type state struct {
+	node *Trie
+	i    int
+}
+
+// Returns the words matching pattern in lexicographic order.
+// ? matches any single rune, * any run of runes and [...] a character class.
+// See compilePattern for the full syntax.
+//
+// Returns ErrBadPattern if the pattern is malformed or not valid UTF-8.
+func (t *Trie) Match(pattern string) ([]string, error) {
+	tokens, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	words := []string{}
+	seen := map[state]bool{}
+	matchRec(t, "", tokens, 0, seen, &words)
+
+	sort.Strings(words)
+	return words, nil
+}
+
+// matches tokens[i:] against the subtree at node, reached by word.
+// Each (node, i) pair is explored once, which bounds the work done by stars.
+func matchRec(node *Trie, word string, tokens []token, i int, seen map[state]bool, words *[]string) {
+	s := state{node, i}
+	if seen[s] {
+		return
+	}
+	seen[s] = true
+
+	if i == len(tokens) {
+		if node.isWordEnd {
+			*words = append(*words, word)
+		}
+		return
+	}
+
+	tok := &tokens[i]
+	if tok.kind == tokenStar {
+		// the star matches nothing more...
+		matchRec(node, word, tokens, i+1, seen, words)
+
+		// ...or one more rune
+		for _, e := range node.children {
+			matchRec(e.node, word+string(e.char), tokens, i, seen, words)
+		}
+		return
+	}
+
+	if tok.kind == tokenLiteral {
+		if child, ok := node.child(tok.char); ok {
+			matchRec(child, word+string(tok.char), tokens, i+1, seen, words)
+		}
+		return
+	}
+
+	for _, e := range node.children {
+		if tok.matches(e.char) {
+			matchRec(e.node, word+string(e.char), tokens, i+1, seen, words)
+		}
+	}
+}
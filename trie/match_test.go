@@ -0,0 +1,49 @@
+package trie_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abiiranathan/algo/trie"
+)
+
+func TestTrieMatch(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.Insert("cat", "cot", "cut", "coat", "cart", "dog", "dig", "a*b", "東京")
+
+	cases := []struct {
+		pattern string
+		want    string
+	}{
+		{"c?t", "cat cot cut"},
+		{"c*t", "cart cat coat cot cut"},
+		{"c[ao]t", "cat cot"},
+		{"c[^ao]t", "cut"},
+		{"c[!a-o]t", "cut"},
+		{"[a-d]?g", "dig dog"},
+		{"*", "a*b cart cat coat cot cut dig dog 東京"},
+		{"**o*", "coat cot dog"},
+		{`a\*b`, "a*b"},
+		{"東?", "東京"},
+		{"x*", ""},
+		{"ca", ""},
+	}
+
+	for _, c := range cases {
+		got, err := tr.Match(c.pattern)
+		if err != nil {
+			t.Errorf("Match(%q) returned an error: %v", c.pattern, err)
+			continue
+		}
+
+		if strings.Join(got, " ") != c.want {
+			t.Errorf("Match(%q) = %v, want %s", c.pattern, got, c.want)
+		}
+	}
+
+	for _, bad := range []string{"c[at", "c[]", `c\`, "[z-a]"} {
+		if _, err := tr.Match(bad); err != trie.ErrBadPattern {
+			t.Errorf("Match(%q) should return ErrBadPattern, got %v", bad, err)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package trie
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/abiiranathan/algo/internal/countio"
+)
+
+// ErrBadFormat is returned by ReadFrom when the input is not a serialized trie.
+var ErrBadFormat = errors.New("trie: invalid serialized trie")
+
+// ErrInvalidUTF8 is returned by Builder.Add when a word is not valid UTF-8.
+var ErrInvalidUTF8 = errors.New("trie: word is not valid UTF-8")
+
+// ErrUnsorted is returned by Builder.Add when words are not added in order.
+var ErrUnsorted = errors.New("trie: words must be added in sorted order")
+
+// magic header of the binary format, followed by a version byte.
+const magic = "TRIE\x01"
+
+// node flags in the binary format
+const flagWordEnd = 1
+
+// WriteTo writes the trie to w in a compact binary format.
+// Nodes are written depth first with their children in rune order,
+// so equal tries always produce identical output.
+//
+// Implements io.WriterTo.
+func (t *Trie) WriteTo(w io.Writer) (int64, error) {
+	cw := countio.NewWriter(w)
+	bw := bufio.NewWriter(cw)
+
+	if _, err := bw.WriteString(magic); err != nil {
+		return cw.Count(), err
+	}
+
+	if err := writeNode(bw, t); err != nil {
+		return cw.Count(), err
+	}
+
+	err := bw.Flush()
+	return cw.Count(), err
+}
+
+// writes node and its subtree to w.
+func writeNode(w *bufio.Writer, node *Trie) error {
+	var buf [binary.MaxVarintLen64]byte
+
+	if node.isWordEnd {
+		w.WriteByte(flagWordEnd)
+		binary.LittleEndian.PutUint64(buf[:8], math.Float64bits(node.weight))
+		w.Write(buf[:8])
+	} else {
+		w.WriteByte(0)
+	}
+
+	// children are already sorted by rune
+	w.Write(buf[:binary.PutUvarint(buf[:], uint64(len(node.children)))])
+	for _, e := range node.children {
+		w.Write(buf[:binary.PutUvarint(buf[:], uint64(e.char))])
+		if err := writeNode(w, e.node); err != nil {
+			return err
+		}
+	}
+
+	// bufio.Writer errors are sticky, so checking once per node is enough
+	_, err := w.Write(nil)
+	return err
+}
+
+// ReadFrom replaces the contents of the trie with a trie
+// previously written by WriteTo.
+//
+// Implements io.ReaderFrom.
+func (t *Trie) ReadFrom(r io.Reader) (int64, error) {
+	cr := countio.NewReader(r)
+
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(cr, header); err != nil || string(header) != magic {
+		return cr.Count(), ErrBadFormat
+	}
+
+	root, err := readNode(cr)
+	if err != nil {
+		return cr.Count(), err
+	}
+
+	*t = *root
+	return cr.Count(), nil
+}
+
+// reads a node and its subtree, recomputing the best weights.
+func readNode(r *countio.Reader) (*Trie, error) {
+	node := NewTrie()
+
+	flags, err := r.ReadByte()
+	if err != nil || flags&^flagWordEnd != 0 {
+		return nil, ErrBadFormat
+	}
+
+	if flags&flagWordEnd != 0 {
+		var buf [8]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return nil, ErrBadFormat
+		}
+		node.isWordEnd = true
+		node.weight = math.Float64frombits(binary.LittleEndian.Uint64(buf[:]))
+		node.best = node.weight
+	}
+
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, ErrBadFormat
+	}
+
+	for i := uint64(0); i < n; i++ {
+		x, err := binary.ReadUvarint(r)
+		if err != nil || x > math.MaxInt32 || !utf8.ValidRune(rune(x)) {
+			return nil, ErrBadFormat
+		}
+
+		// children must be strictly ascending to keep the order invariant
+		if l := len(node.children); l > 0 && rune(x) <= node.children[l-1].char {
+			return nil, ErrBadFormat
+		}
+
+		child, err := readNode(r)
+		if err != nil {
+			return nil, err
+		}
+
+		node.children = append(node.children, edge{char: rune(x), node: child})
+		if child.best > node.best {
+			node.best = child.best
+		}
+	}
+	return node, nil
+}
+
+// Builder constructs a Trie from words added in sorted order.
+//
+// It keeps the path to the previous word, so each word only walks
+// down from where it diverges from the previous one instead of
+// from the root.
+type Builder struct {
+	trie  *Trie
+	path  []*Trie // path[i] is the node after i runes of prev
+	prev  []rune
+	first bool
+}
+
+// Creates a new Builder.
+func NewBuilder() *Builder {
+	t := NewTrie()
+	return &Builder{trie: t, path: []*Trie{t}, first: true}
+}
+
+// Adds word to the trie with a weight of 0. Words must be added in
+// ascending order; duplicates are ignored.
+// Returns ErrUnsorted if word sorts before the previous word and
+// ErrInvalidUTF8 if it is not valid UTF-8.
+func (b *Builder) Add(word string) error {
+	if !utf8.ValidString(word) {
+		return ErrInvalidUTF8
+	}
+
+	runes := []rune(word)
+
+	// length of the common prefix with the previous word
+	l := 0
+	for l < len(runes) && l < len(b.prev) && runes[l] == b.prev[l] {
+		l++
+	}
+
+	if !b.first {
+		// runes compare like the UTF-8 bytes of the words
+		if l < len(runes) && l < len(b.prev) && runes[l] < b.prev[l] {
+			return ErrUnsorted
+		}
+
+		if l == len(runes) && l < len(b.prev) {
+			return ErrUnsorted
+		}
+	}
+	b.first = false
+
+	b.path = b.path[:l+1]
+	node := b.path[l]
+	for _, x := range runes[l:] {
+		// sorted input means new children always sort last
+		child := NewTrie()
+		node.children = append(node.children, edge{char: x, node: child})
+		node = child
+		b.path = append(b.path, node)
+	}
+
+	if !node.isWordEnd {
+		node.isWordEnd = true
+		for _, n := range b.path {
+			if n.best < 0 {
+				n.best = 0
+			}
+		}
+	}
+
+	b.prev = runes
+	return nil
+}
+
+// Returns the trie built so far. The Builder must not be used afterwards.
+func (b *Builder) Trie() *Trie {
+	return b.trie
+}
+
+// Loads a newline separated word list into a new trie.
+// Empty lines and lines that are not valid UTF-8 are skipped,
+// and a trailing carriage return is removed.
+//
+// Sorted input is loaded with a Builder; if a word is out of order
+// the remaining words are inserted one by one.
+func LoadWords(r io.Reader) (*Trie, error) {
+	scanner := bufio.NewScanner(r)
+	b := NewBuilder()
+	var t *Trie
+
+	for scanner.Scan() {
+		word := strings.TrimSuffix(scanner.Text(), "\r")
+		if word == "" || !utf8.ValidString(word) {
+			continue
+		}
+
+		if t != nil {
+			t.Insert(word)
+			continue
+		}
+
+		if err := b.Add(word); err != nil {
+			t = b.Trie()
+			t.Insert(word)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if t == nil {
+		t = b.Trie()
+	}
+	return t, nil
+}
@@ -0,0 +1,130 @@
+package trie_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/abiiranathan/algo/trie"
+)
+
+func TestTrieWriteReadFrom(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.Insert("cat", "cattle", "東京", "")
+	tr.InsertWeighted("cow", 2.5)
+
+	var buf bytes.Buffer
+	n, err := tr.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo reported %d bytes, wrote %d", n, buf.Len())
+	}
+
+	// the encoding is deterministic
+	var again bytes.Buffer
+	tr.WriteTo(&again)
+	if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+		t.Errorf("WriteTo should produce identical output for the same trie")
+	}
+
+	loaded := trie.NewTrie()
+	loaded.Insert("stale")
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Size() != 5 || loaded.Nodes() != tr.Nodes() || loaded.Exists("stale") {
+		t.Errorf("loaded trie does not match, got %v", loaded.SortedWords())
+	}
+
+	if w, ok := loaded.Weight("cow"); !ok || w != 2.5 {
+		t.Errorf("weights should be preserved, got %v", w)
+	}
+
+	if top := loaded.TopK("c", 1); len(top) != 1 || top[0] != "cow" {
+		t.Errorf("best weights should be rebuilt on load, got %v", top)
+	}
+
+	for _, bad := range []string{"", "TRIE", "NOPE\x01\x00\x00", "TRIE\x01\x00\x05"} {
+		if _, err := trie.NewTrie().ReadFrom(strings.NewReader(bad)); err != trie.ErrBadFormat {
+			t.Errorf("ReadFrom(%q) should return ErrBadFormat, got %v", bad, err)
+		}
+	}
+}
+
+func TestTrieBuilder(t *testing.T) {
+	b := trie.NewBuilder()
+	for _, w := range []string{"a", "ab", "ab", "abc", "b", "bcd", "é"} {
+		if err := b.Add(w); err != nil {
+			t.Fatalf("Add(%q) returned %v", w, err)
+		}
+	}
+
+	if err := b.Add("bc"); err != trie.ErrUnsorted {
+		t.Errorf("expected ErrUnsorted, got %v", err)
+	}
+
+	built := b.Trie()
+	expected := trie.NewTrie()
+	expected.Insert("a", "ab", "abc", "b", "bcd", "é")
+
+	if built.Size() != 6 || built.Nodes() != expected.Nodes() {
+		t.Errorf("builder produced %v in %d nodes", built.SortedWords(), built.Nodes())
+	}
+
+	if top := built.TopK("", 2); len(top) != 2 || top[0] != "a" {
+		t.Errorf("built trie should support TopK, got %v", top)
+	}
+}
+
+func TestLoadWords(t *testing.T) {
+	sorted := "apple\r\nbanana\n\ncherry\n"
+	tr, err := trie.LoadWords(strings.NewReader(sorted))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tr.Size() != 3 || !tr.Exists("apple") || !tr.Exists("cherry") {
+		t.Errorf("unexpected words %v", tr.SortedWords())
+	}
+
+	// unsorted input falls back to Insert
+	tr, err = trie.LoadWords(strings.NewReader("pear\napple\nzebra\nbanana\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tr.Size() != 4 || !tr.Exists("apple") || !tr.Exists("banana") {
+		t.Errorf("unexpected words %v", tr.SortedWords())
+	}
+}
+
+// sorted word list shared by the load benchmarks
+var benchWords = func() string {
+	var sb strings.Builder
+	for a := 'a'; a <= 'z'; a++ {
+		for b := 'a'; b <= 'z'; b++ {
+			for c := 'a'; c <= 'z'; c++ {
+				sb.WriteString(string([]rune{a, b, c}) + "suffix\n")
+			}
+		}
+	}
+	return sb.String()
+}()
+
+func BenchmarkLoadWords(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		trie.LoadWords(strings.NewReader(benchWords))
+	}
+}
+
+func BenchmarkInsertWords(b *testing.B) {
+	words := strings.Fields(benchWords)
+	for i := 0; i < b.N; i++ {
+		tr := trie.NewTrie()
+		tr.Insert(words...)
+	}
+}
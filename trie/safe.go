@@ -0,0 +1,189 @@
+package trie
+
+import (
+	"io"
+	"iter"
+	"sync"
+)
+
+// SafeTrie is a Trie guarded by a sync.RWMutex, safe for concurrent use.
+//
+// Lookups take the read lock and run in parallel; writes take the write
+// lock. Suited to read-mostly workloads such as autocomplete served to
+// many goroutines while an indexer occasionally inserts terms.
+type SafeTrie struct {
+	trie  *Trie
+	mutex *sync.RWMutex
+}
+
+// initializes a new SafeTrie
+func NewSafeTrie() *SafeTrie {
+	return &SafeTrie{trie: NewTrie(), mutex: &sync.RWMutex{}}
+}
+
+// Wraps an existing trie. t must not be used directly afterwards.
+func NewSafeTrieFrom(t *Trie) *SafeTrie {
+	return &SafeTrie{trie: t, mutex: &sync.RWMutex{}}
+}
+
+// Insert one or more words into the trie. See Trie.Insert.
+func (s *SafeTrie) Insert(words ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.trie.Insert(words...)
+}
+
+// Insert word with a weight. See Trie.InsertWeighted.
+func (s *SafeTrie) InsertWeighted(word string, weight float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.trie.InsertWeighted(word, weight)
+}
+
+// Removes word from the trie. See Trie.Delete.
+func (s *SafeTrie) Delete(word string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.trie.Delete(word)
+}
+
+// Removes every word starting with prefix. See Trie.DeletePrefix.
+func (s *SafeTrie) DeletePrefix(prefix string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.trie.DeletePrefix(prefix)
+}
+
+// Returns true if word exists in the trie.
+func (s *SafeTrie) Exists(word string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.Exists(word)
+}
+
+// Returns the weight of word and true if word is in the trie.
+func (s *SafeTrie) Weight(word string) (float64, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.Weight(word)
+}
+
+// Returns a slice of words starting with query. See Trie.Suggestions.
+func (s *SafeTrie) Suggestions(query string) []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.Suggestions(query)
+}
+
+// Returns an iterator over the words starting with prefix,
+// in lexicographic order. See Trie.Walk.
+//
+// It iterates over a snapshot taken under the read lock when iteration
+// starts, so the loop body may safely modify the trie.
+func (s *SafeTrie) Walk(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, word := range s.Suggestions(prefix) {
+			if !yield(word) {
+				return
+			}
+		}
+	}
+}
+
+// Returns up to limit words starting with prefix that sort after cursor.
+// See Trie.SuggestionsAfter.
+func (s *SafeTrie) SuggestionsAfter(prefix, cursor string, limit int) []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.SuggestionsAfter(prefix, cursor, limit)
+}
+
+// Returns the k highest weighted words starting with prefix. See Trie.TopK.
+func (s *SafeTrie) TopK(prefix string, k int) []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.TopK(prefix, k)
+}
+
+// Returns the words within maxEdits of query. See Trie.FuzzySuggestions.
+func (s *SafeTrie) FuzzySuggestions(query string, maxEdits int) []FuzzyMatch {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.FuzzySuggestions(query, maxEdits)
+}
+
+// Returns the words matching pattern. See Trie.Match.
+func (s *SafeTrie) Match(pattern string) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.Match(pattern)
+}
+
+// Returns all words in the trie in lexicographic order.
+func (s *SafeTrie) Words() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.Words()
+}
+
+// Returns a slice of words sorted alphabetically
+func (s *SafeTrie) SortedWords() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.SortedWords()
+}
+
+// Returns the number of words in the trie.
+func (s *SafeTrie) Size() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.Size()
+}
+
+// Returns the number of nodes in the trie
+func (s *SafeTrie) Nodes() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.Nodes()
+}
+
+// WriteTo writes the trie to w under the read lock. See Trie.WriteTo.
+func (s *SafeTrie) WriteTo(w io.Writer) (int64, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.trie.WriteTo(w)
+}
+
+// ReadFrom replaces the contents of the trie. See Trie.ReadFrom.
+//
+// The input is decoded before the write lock is taken,
+// so readers are only blocked for the swap.
+func (s *SafeTrie) ReadFrom(r io.Reader) (int64, error) {
+	loaded := NewTrie()
+	n, err := loaded.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.trie = loaded
+	return n, nil
+}
@@ -0,0 +1,111 @@
+package trie_test
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/abiiranathan/algo/trie"
+)
+
+// Run with -race to detect unsynchronized access.
+func TestSafeTrieConcurrent(t *testing.T) {
+	s := trie.NewSafeTrie()
+	s.Insert("seed")
+
+	var wg sync.WaitGroup
+
+	// indexers
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				s.InsertWeighted("term"+strconv.Itoa(g*1000+i), float64(i))
+			}
+		}(g)
+	}
+
+	// readers
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				s.Suggestions("term1")
+				s.TopK("term", 5)
+				s.Exists("seed")
+				s.FuzzySuggestions("tern", 1)
+				s.Match("term?")
+				s.Size()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s.Size() != 801 {
+		t.Errorf("expected 801 words, got %d", s.Size())
+	}
+
+	if top := s.TopK("term", 1); len(top) != 1 {
+		t.Errorf("expected a top suggestion, got %v", top)
+	}
+
+	if s.DeletePrefix("term") != 800 || !s.Delete("seed") || s.Size() != 0 || s.Nodes() != 0 {
+		t.Errorf("deletes failed")
+	}
+}
+
+func TestSafeTriePersistence(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.Insert("alpha", "beta")
+	s := trie.NewSafeTrieFrom(tr)
+
+	var buf bytes.Buffer
+	if _, err := s.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := trie.NewSafeTrie()
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if words := loaded.SortedWords(); len(words) != 2 || words[0] != "alpha" {
+		t.Errorf("unexpected words %v", words)
+	}
+
+	if _, err := loaded.ReadFrom(&buf); err != trie.ErrBadFormat {
+		t.Errorf("expected ErrBadFormat, got %v", err)
+	}
+
+	if loaded.Size() != 2 || len(loaded.Words()) != 2 {
+		t.Errorf("a failed ReadFrom should keep the current contents")
+	}
+
+	if w, ok := loaded.Weight("beta"); !ok || w != 0 {
+		t.Errorf("unexpected weight")
+	}
+}
+
+func TestSafeTrieWalk(t *testing.T) {
+	s := trie.NewSafeTrie()
+	s.Insert("car", "cart", "cat", "dog")
+
+	got := []string{}
+	for w := range s.Walk("ca") {
+		// the walk iterates a snapshot, so writing does not deadlock
+		s.Insert(w + "s")
+		got = append(got, w)
+	}
+
+	if want := []string{"car", "cart", "cat"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if s.Size() != 7 {
+		t.Errorf("expected 7 words after inserting during the walk, got %d", s.Size())
+	}
+}
@@ -0,0 +1,70 @@
+package trie
+
+import "container/heap"
+
+// candidate is either a whole subtree, ranked by the best weight in it,
+// or a single word. prefix is a lower bound on the words of a subtree.
+type candidate struct {
+	node   *Trie
+	prefix string
+	score  float64
+	isWord bool
+}
+
+// candidates is a max-heap on score with ties broken by prefix.
+type candidates []candidate
+
+func (c candidates) Len() int { return len(c) }
+
+func (c candidates) Less(i, j int) bool {
+	if c[i].score != c[j].score {
+		return c[i].score > c[j].score
+	}
+	return c[i].prefix < c[j].prefix
+}
+
+func (c candidates) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+
+func (c *candidates) Push(x any) { *c = append(*c, x.(candidate)) }
+
+func (c *candidates) Pop() any {
+	old := *c
+	x := old[len(old)-1]
+	*c = old[:len(old)-1]
+	return x
+}
+
+// Returns the k highest weighted words starting with prefix.
+// Words with equal weights are ordered alphabetically.
+//
+// Each subtree caches its best weight, so only the branches that
+// can contribute to the result are explored.
+func (t *Trie) TopK(prefix string, k int) []string {
+	words := []string{}
+	if k <= 0 {
+		return words
+	}
+
+	node, ok := t.find(prefix)
+	if !ok {
+		return words
+	}
+
+	pq := &candidates{{node: node, prefix: prefix, score: node.best}}
+	for pq.Len() > 0 && len(words) < k {
+		c := heap.Pop(pq).(candidate)
+		if c.isWord {
+			words = append(words, c.prefix)
+			continue
+		}
+
+		if c.node.isWordEnd {
+			heap.Push(pq, candidate{prefix: c.prefix, score: c.node.weight, isWord: true})
+		}
+
+		for _, e := range c.node.children {
+			heap.Push(pq, candidate{node: e.node, prefix: c.prefix + string(e.char), score: e.node.best})
+		}
+	}
+	return words
+}
@@ -0,0 +1,56 @@
+package trie_test
+
+import (
+	"testing"
+
+	"github.com/abiiranathan/algo/trie"
+)
+
+func TestTrieTopK(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.InsertWeighted("paracetamol", 50)
+	tr.InsertWeighted("panadol", 80)
+	tr.InsertWeighted("pantoprazole", 20)
+	tr.InsertWeighted("penicillin", 80)
+	tr.InsertWeighted("pan", 5)
+	tr.Insert("amoxicillin")
+
+	expect := func(got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+		}
+	}
+
+	// equal weights are ordered alphabetically
+	expect(tr.TopK("p", 3), "panadol", "penicillin", "paracetamol")
+	expect(tr.TopK("pa", 10), "panadol", "paracetamol", "pantoprazole", "pan")
+	expect(tr.TopK("", 1), "panadol")
+	expect(tr.TopK("x", 3))
+	expect(tr.TopK("p", 0))
+
+	if w, ok := tr.Weight("amoxicillin"); !ok || w != 0 {
+		t.Errorf("Insert should give new words a weight of 0")
+	}
+
+	// Insert keeps the existing weight
+	tr.Insert("panadol")
+	if w, _ := tr.Weight("panadol"); w != 80 {
+		t.Errorf("Insert should not reset the weight, got %v", w)
+	}
+
+	// lowering a weight updates the cached subtree bests
+	tr.InsertWeighted("panadol", 1)
+	expect(tr.TopK("pan", 2), "pantoprazole", "pan")
+
+	tr.Delete("pantoprazole")
+	expect(tr.TopK("pan", 1), "pan")
+
+	tr.DeletePrefix("pe")
+	expect(tr.TopK("", 2), "paracetamol", "pan")
+}
@@ -1,62 +1,170 @@
-// Effecient Trie data structure implementation using a map.
+// Effecient Trie data structure implementation using sorted child slices.
 //
-// It can store all UTF-8 characters in runes as supported in golang.package trie
+// It can store all UTF-8 characters in runes as supported in golang.
+// Words are traversed rune by rune, so multi-byte characters occupy a single node.
+// Words must be valid UTF-8: invalid words are not inserted and never match,
+// since distinct invalid byte sequences would all decode to utf8.RuneError.
+// Children are kept sorted by rune, so traversals yield words in
+// lexicographic order.
 package trie
 
-import "sort"
+import (
+	"iter"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
 
-// Trie holds the data in a prefix tree using an unordered map
+// Trie holds the data in a prefix tree whose children are sorted by rune.
 type Trie struct {
-	hash      map[rune]*Trie
+	children  []edge
 	isWordEnd bool
+
+	weight float64 // weight of the word ending at this node
+	best   float64 // highest weight of any word in this subtree
+}
+
+// edge links a node to the child reached by char.
+type edge struct {
+	char rune
+	node *Trie
 }
 
 // initializes a new Trie
 func NewTrie() *Trie {
 	return &Trie{
-		hash:      map[rune]*Trie{},
 		isWordEnd: false,
+		best:      math.Inf(-1),
+	}
+}
+
+// Returns the index of the first child whose rune is >= x.
+func (t *Trie) search(x rune) int {
+	return sort.Search(len(t.children), func(i int) bool {
+		return t.children[i].char >= x
+	})
+}
+
+// Returns the child reached by x.
+//
+// O(log k) time complexity where k is the number of children.
+func (t *Trie) child(x rune) (*Trie, bool) {
+	i := t.search(x)
+	if i < len(t.children) && t.children[i].char == x {
+		return t.children[i].node, true
+	}
+	return nil, false
+}
+
+// Returns the child reached by x, creating it in sorted position if missing.
+func (t *Trie) addChild(x rune) *Trie {
+	i := t.search(x)
+	if i < len(t.children) && t.children[i].char == x {
+		return t.children[i].node
+	}
+
+	node := NewTrie()
+	t.children = append(t.children, edge{})
+	copy(t.children[i+1:], t.children[i:])
+	t.children[i] = edge{char: x, node: node}
+	return node
+}
+
+// Removes the child reached by x, if any.
+func (t *Trie) removeChild(x rune) {
+	i := t.search(x)
+	if i < len(t.children) && t.children[i].char == x {
+		t.children = append(t.children[:i], t.children[i+1:]...)
 	}
 }
 
 // Insert one or more words into the Tri.
+// New words get a weight of 0; words already in the trie keep their weight.
+// Words that are not valid UTF-8 are ignored.
 //
-// Insertion is O(m) where m is the number of letters in the word.
+// Insertion is O(m) where m is the number of runes in the word.
 func (t *Trie) Insert(words ...string) {
 	for _, word := range words {
-		var temp *Trie
-		temp = t
+		t.insert(word, 0, false)
+	}
+}
+
+// Insert word with a weight used to rank it in TopK,
+// replacing the weight if word is already in the trie.
+// A word that is not valid UTF-8 is ignored.
+func (t *Trie) InsertWeighted(word string, weight float64) {
+	t.insert(word, weight, true)
+}
+
+// inserts word, setting its weight if the word is new or setWeight is true.
+func (t *Trie) insert(word string, weight float64, setWeight bool) {
+	if !utf8.ValidString(word) {
+		return
+	}
 
-		for i := 0; i < len(word); i++ {
-			x := rune(word[i])
+	path := []*Trie{t}
+	temp := t
 
-			// make the node if there is no path
-			if _, ok := temp.hash[x]; !ok {
-				temp.hash[x] = NewTrie()
+	for _, x := range word {
+		// make the node if there is no path
+		temp = temp.addChild(x)
+		path = append(path, temp)
+	}
+
+	if temp.isWordEnd && !setWeight {
+		return
+	}
+
+	old, existed := temp.weight, temp.isWordEnd
+	temp.isWordEnd = true
+	temp.weight = weight
+
+	// a heavier word only raises the best weights along its path
+	if !existed || weight >= old {
+		for _, node := range path {
+			if weight > node.best {
+				node.best = weight
 			}
-			temp = temp.hash[x]
 		}
-		temp.isWordEnd = true
+		return
+	}
+	refreshBest(path)
+}
+
+// Returns the weight of word and true if word is in the trie.
+func (t *Trie) Weight(word string) (float64, bool) {
+	temp, ok := t.find(word)
+	if !ok {
+		return 0, false
 	}
+	return temp.weight, temp.isWordEnd
 }
 
 // Returns true if word exists in the trie.
 //
 // O(m) time complexity
 func (t *Trie) Exists(word string) bool {
-	var temp *Trie
-	temp = t
+	temp, ok := t.find(word)
+	return ok && temp.isWordEnd
+}
+
+// Returns the node reached by prefix. Fails for prefixes that are
+// not valid UTF-8, as those can never have been inserted.
+func (t *Trie) find(prefix string) (*Trie, bool) {
+	if !utf8.ValidString(prefix) {
+		return nil, false
+	}
 
-	for i := 0; i < len(word); i++ {
-		x := rune(word[i])
-		node, ok := temp.hash[x]
+	temp := t
+	for _, x := range prefix {
+		node, ok := temp.child(x)
 		if !ok {
-			return false
+			return nil, false
 		}
 		temp = node
-
 	}
-	return temp.isWordEnd
+	return temp, true
 }
 
 // Recursive function to find all words on the node root.
@@ -68,64 +176,126 @@ func (t *Trie) suggestionRec(root *Trie, prefix string, words *[]string) {
 		*words = append(*words, prefix)
 	}
 
-	// recursively explore all the nodes in the map
-	for char, node := range root.hash {
-		// base case as there is no recursion if node is nil
-		if node != nil {
-			t.suggestionRec(node, prefix+string(char), words)
-		}
+	// recursively explore the children in rune order
+	for _, e := range root.children {
+		t.suggestionRec(e.node, prefix+string(e.char), words)
 	}
 }
 
-// Returns a slice of words matching query from the trie.
+// Returns a slice of words matching query from the trie,
+// in lexicographic order.
 // Worst case: O(m+n) where m is the number of characters in query
 // and n is the number of nodes in the trie.
 func (t *Trie) Suggestions(query string) []string {
 	words := []string{}
-	wordLen := len(query)
-
-	currentNode := t
-
-	for i := 0; i < wordLen; i++ {
-		x := rune(query[i])
-		node, ok := currentNode.hash[x]
-		if !ok {
-			return []string{}
-		}
-		currentNode = node
+	currentNode, ok := t.find(query)
+	if !ok {
+		return words
 	}
 
 	t.suggestionRec(currentNode, query, &words)
 	return words
 }
 
-// Returns all words in the trie in no particular order.
+// Returns all words in the trie in lexicographic order.
 func (t *Trie) Words() []string {
 	words := []string{}
 	t.suggestionRec(t, "", &words)
 	return words
 }
 
-// Returns a slice of words sorted alphabetically
+// Returns a slice of words sorted alphabetically.
+//
+// Words is already sorted; SortedWords is kept for compatibility.
 func (t *Trie) SortedWords() []string {
-	words := t.Words()
-	sort.Slice(words, func(i, j int) bool {
-		return words[i] < words[j]
-	})
+	return t.Words()
+}
+
+// Returns an iterator over the words starting with prefix,
+// in lexicographic order. Stopping the iteration early skips
+// the rest of the traversal.
+//
+// The trie must not be modified during iteration.
+func (t *Trie) Walk(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		node, ok := t.find(prefix)
+		if !ok {
+			return
+		}
+		walkRec(node, prefix, yield)
+	}
+}
+
+// Yields the words in root's subtree in order.
+// Returns false once yield asks to stop.
+func walkRec(root *Trie, word string, yield func(string) bool) bool {
+	if root.isWordEnd && !yield(word) {
+		return false
+	}
+
+	for _, e := range root.children {
+		if !walkRec(e.node, word+string(e.char), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns up to limit words starting with prefix that sort after cursor,
+// in lexicographic order. Pass the last word of the previous page as
+// cursor to fetch the next page, or "" to start from the beginning.
+//
+// Subtrees that sort entirely before cursor are skipped, so a page
+// costs O(m*k + limit) rather than a walk over the earlier pages,
+// where m is the length of cursor and k the children per node.
+func (t *Trie) SuggestionsAfter(prefix, cursor string, limit int) []string {
+	words := []string{}
+	if limit <= 0 {
+		return words
+	}
+
+	node, ok := t.find(prefix)
+	if !ok {
+		return words
+	}
+
+	afterRec(node, prefix, cursor, limit, &words)
 	return words
 }
 
+// Collects words in root's subtree that sort after cursor until
+// limit words are found. Returns false once the page is full.
+func afterRec(root *Trie, word, cursor string, limit int, words *[]string) bool {
+	if root.isWordEnd && (cursor == "" || word > cursor) {
+		*words = append(*words, word)
+		if len(*words) == limit {
+			return false
+		}
+	}
+
+	for _, e := range root.children {
+		next := word + string(e.char)
+
+		// every word below next sorts before cursor
+		if cursor != "" && next < cursor && !strings.HasPrefix(cursor, next) {
+			continue
+		}
+
+		if !afterRec(e.node, next, cursor, limit, words) {
+			return false
+		}
+	}
+	return true
+}
+
 // Recursive function to find wordcount along the node root in trie.
 func (t *Trie) sizeRec(root *Trie, wordCount *int, prefix string) {
 	if root.isWordEnd {
 		(*wordCount)++
 	}
 
-	for char, node := range root.hash {
-		// base case as there is no recursion if node is nil
-		if node != nil {
-			t.sizeRec(node, wordCount, prefix+string(char))
-		}
+	for _, e := range root.children {
+		t.sizeRec(e.node, wordCount, prefix+string(e.char))
 	}
 }
 
@@ -139,11 +309,9 @@ func (t *Trie) Size() int {
 // Recursively counts the number of child nodes in root node
 // adding to the count.
 func countNodesRec(root *Trie, count *int) {
-	for _, v := range root.hash {
-		if v != nil {
-			(*count)++
-			countNodesRec(v, count)
-		}
+	for _, e := range root.children {
+		(*count)++
+		countNodesRec(e.node, count)
 	}
 }
 
@@ -153,3 +321,110 @@ func (t *Trie) Nodes() int {
 	countNodesRec(t, &count)
 	return count
 }
+
+// Removes word from the trie, pruning branches left without words.
+// Returns false if word was not in the trie.
+//
+// O(m) time complexity
+func (t *Trie) Delete(word string) bool {
+	if !utf8.ValidString(word) {
+		return false
+	}
+
+	path := []*Trie{t}
+	runes := []rune{}
+
+	temp := t
+	for _, x := range word {
+		node, ok := temp.child(x)
+		if !ok {
+			return false
+		}
+		temp = node
+		path = append(path, node)
+		runes = append(runes, x)
+	}
+
+	if !temp.isWordEnd {
+		return false
+	}
+
+	temp.isWordEnd = false
+	temp.weight = 0
+	prune(path, runes)
+	refreshBest(path)
+	return true
+}
+
+// Removes every word starting with prefix, including prefix itself.
+// Returns the number of words removed.
+func (t *Trie) DeletePrefix(prefix string) int {
+	if prefix == "" {
+		n := t.Size()
+		t.children = nil
+		t.isWordEnd = false
+		t.weight = 0
+		t.best = math.Inf(-1)
+		return n
+	}
+
+	if !utf8.ValidString(prefix) {
+		return 0
+	}
+
+	path := []*Trie{t}
+	runes := []rune{}
+
+	temp := t
+	for _, x := range prefix {
+		node, ok := temp.child(x)
+		if !ok {
+			return 0
+		}
+		temp = node
+		path = append(path, node)
+		runes = append(runes, x)
+	}
+
+	n := 0
+	t.sizeRec(temp, &n, prefix)
+
+	// detach the subtree, then prune its now empty ancestors
+	last := len(runes) - 1
+	path[last].removeChild(runes[last])
+	prune(path[:last+1], runes[:last])
+	refreshBest(path[:last+1])
+	return n
+}
+
+// prune walks path from the deepest node towards the root, removing
+// nodes that neither end a word nor have children.
+// runes[i] is the edge from path[i] to path[i+1].
+func prune(path []*Trie, runes []rune) {
+	for i := len(path) - 1; i > 0; i-- {
+		node := path[i]
+		if node.isWordEnd || len(node.children) > 0 {
+			return
+		}
+		path[i-1].removeChild(runes[i-1])
+	}
+}
+
+// refreshBest recomputes the best weight of each node in path,
+// from the deepest node towards the root.
+func refreshBest(path []*Trie) {
+	for i := len(path) - 1; i >= 0; i-- {
+		node := path[i]
+
+		node.best = math.Inf(-1)
+		if node.isWordEnd {
+			node.best = node.weight
+		}
+
+		for _, e := range node.children {
+			if e.node.best > node.best {
+				node.best = e.node.best
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package trie_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/abiiranathan/algo/trie"
@@ -92,3 +93,227 @@ func TestTrie(t *testing.T) {
 		t.Errorf("expected number of nodes to be %d, got %d", 10, tr2.Nodes())
 	}
 }
+
+func TestTrieUnicode(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.Insert("café", "cafés", "webale", "wébale", "東京", "東京都", "京都")
+
+	if tr.Size() != 7 {
+		t.Errorf("expected 7 words, got %d", tr.Size())
+	}
+
+	for _, w := range []string{"café", "wébale", "東京", "京都"} {
+		if !tr.Exists(w) {
+			t.Errorf("expected %q to exist in trie", w)
+		}
+	}
+
+	// a prefix of the UTF-8 bytes of é is not a word
+	if tr.Exists("caf\xc3") || tr.Exists("東") {
+		t.Errorf("partial characters should not exist in trie")
+	}
+
+	sorted := tr.SortedWords()
+	expected := []string{"café", "cafés", "webale", "wébale", "京都", "東京", "東京都"}
+	for i, w := range expected {
+		if sorted[i] != w {
+			t.Errorf("expected word %d to be %q, got %q", i, w, sorted[i])
+		}
+	}
+
+	matches := tr.Suggestions("東京")
+	if len(matches) != 2 {
+		t.Errorf("expected 2 suggestions for 東京, got %v", matches)
+	}
+
+	matches = tr.Suggestions("caf")
+	if len(matches) != 2 {
+		t.Errorf("expected 2 suggestions for caf, got %v", matches)
+	}
+
+	// one node per rune: c-a-f-é-s, w-e-b-a-l-e, w-é-b-a-l-e, 東-京-都, 京-都
+	if tr.Nodes() != 5+6+5+3+2 {
+		t.Errorf("expected %d nodes, got %d", 5+6+5+3+2, tr.Nodes())
+	}
+}
+
+func TestTrieInvalidUTF8(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.Insert("a\xffb", "ab")
+	tr.InsertWeighted("\xfe", 1)
+
+	// invalid bytes would otherwise all decode to U+FFFD and collide
+	if tr.Size() != 1 || tr.Exists("a\xffb") || tr.Exists("a\xfeb") {
+		t.Errorf("invalid UTF-8 should not be inserted, got %q", tr.Words())
+	}
+
+	tr.Insert("a\uFFFDb")
+	if tr.Exists("a\xffb") || !tr.Exists("a\uFFFDb") {
+		t.Errorf("an invalid byte should not match a stored U+FFFD")
+	}
+
+	if len(tr.Suggestions("a\xff")) != 0 || tr.Delete("a\xffb") || tr.DeletePrefix("a\xff") != 0 {
+		t.Errorf("lookups with invalid UTF-8 should not match")
+	}
+
+	if _, err := tr.Match("a\xff*"); err != trie.ErrBadPattern {
+		t.Errorf("expected ErrBadPattern, got %v", err)
+	}
+
+	b := trie.NewBuilder()
+	if err := b.Add("\xff"); err != trie.ErrInvalidUTF8 {
+		t.Errorf("expected ErrInvalidUTF8, got %v", err)
+	}
+}
+
+func TestTrieDelete(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.Insert("car", "cart", "carton", "cat", "dog")
+	nodes := tr.Nodes()
+
+	if tr.Delete("ca") || tr.Delete("cow") {
+		t.Errorf("Delete should return false for words not in trie")
+	}
+
+	if !tr.Delete("cart") {
+		t.Errorf("Delete should return true for a word in trie")
+	}
+
+	if tr.Exists("cart") || !tr.Exists("carton") {
+		t.Errorf("Delete should only remove the given word")
+	}
+
+	// cart is on the path to carton, so no nodes are pruned
+	if tr.Nodes() != nodes {
+		t.Errorf("expected %d nodes, got %d", nodes, tr.Nodes())
+	}
+
+	// removes t-o-n now that cart is gone
+	tr.Delete("carton")
+	if tr.Nodes() != nodes-3 {
+		t.Errorf("expected %d nodes after pruning, got %d", nodes-3, tr.Nodes())
+	}
+
+	if tr.Delete("cart") {
+		t.Errorf("Delete should return false for an already deleted word")
+	}
+
+	tr.Delete("dog")
+	if tr.Nodes() != 4 || tr.Size() != 2 {
+		t.Errorf("expected only car and cat to remain, got %v", tr.Words())
+	}
+}
+
+func TestTrieDeletePrefix(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.Insert("car", "cart", "carton", "cat", "dog")
+
+	if n := tr.DeletePrefix("cars"); n != 0 {
+		t.Errorf("expected no words removed, got %d", n)
+	}
+
+	if n := tr.DeletePrefix("car"); n != 3 {
+		t.Errorf("expected 3 words removed, got %d", n)
+	}
+
+	if tr.Size() != 2 || !tr.Exists("cat") {
+		t.Errorf("expected cat and dog to remain, got %v", tr.Words())
+	}
+
+	// c-a-t and d-o-g
+	if tr.Nodes() != 6 {
+		t.Errorf("expected 6 nodes, got %d", tr.Nodes())
+	}
+
+	tr.DeletePrefix("dog")
+	if tr.Nodes() != 3 {
+		t.Errorf("expected 3 nodes after removing dog, got %d", tr.Nodes())
+	}
+
+	if n := tr.DeletePrefix(""); n != 1 || tr.Size() != 0 || tr.Nodes() != 0 {
+		t.Errorf("empty prefix should clear the trie")
+	}
+}
+
+func TestTrieOrder(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.Insert("zebra", "apple", "éclair", "app", "banana", "ant", "été")
+
+	want := []string{"ant", "app", "apple", "banana", "zebra", "éclair", "été"}
+	if got := tr.Words(); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := tr.Suggestions("a"); !slices.Equal(got, want[:3]) {
+		t.Errorf("expected %v, got %v", want[:3], got)
+	}
+
+	tr.Delete("app")
+	tr.Insert("aardvark")
+	want = []string{"aardvark", "ant", "apple", "banana", "zebra", "éclair", "été"}
+	if got := tr.Words(); !slices.Equal(got, want) {
+		t.Errorf("expected %v after updates, got %v", want, got)
+	}
+}
+
+func TestTrieWalk(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.Insert("car", "cat", "cart", "dog", "carton")
+
+	got := slices.Collect(tr.Walk("car"))
+	want := []string{"car", "cart", "carton"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// stop after the first two words
+	got = got[:0]
+	for w := range tr.Walk("") {
+		got = append(got, w)
+		if len(got) == 2 {
+			break
+		}
+	}
+
+	if !slices.Equal(got, []string{"car", "cart"}) {
+		t.Errorf("expected early exit after two words, got %v", got)
+	}
+
+	if n := len(slices.Collect(tr.Walk("x"))); n != 0 {
+		t.Errorf("expected no words for missing prefix, got %d", n)
+	}
+}
+
+func TestTrieSuggestionsAfter(t *testing.T) {
+	tr := trie.NewTrie()
+	tr.Insert("car", "card", "care", "cared", "cars", "cat", "dog")
+
+	pages := [][]string{}
+	cursor := ""
+	for {
+		page := tr.SuggestionsAfter("ca", cursor, 2)
+		if len(page) == 0 {
+			break
+		}
+		pages = append(pages, page)
+		cursor = page[len(page)-1]
+	}
+
+	want := [][]string{{"car", "card"}, {"care", "cared"}, {"cars", "cat"}}
+	if !slices.EqualFunc(pages, want, slices.Equal) {
+		t.Errorf("expected pages %v, got %v", want, pages)
+	}
+
+	// cursor need not be a word in the trie
+	if got := tr.SuggestionsAfter("", "cb", 10); !slices.Equal(got, []string{"dog"}) {
+		t.Errorf("expected [dog], got %v", got)
+	}
+
+	if got := tr.SuggestionsAfter("car", "ca", 1); !slices.Equal(got, []string{"car"}) {
+		t.Errorf("expected [car], got %v", got)
+	}
+
+	if got := tr.SuggestionsAfter("ca", "", 0); len(got) != 0 {
+		t.Errorf("expected no words for zero limit, got %v", got)
+	}
+}